@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Arm is one side of an Experiment: a control (single-LLM) arm or a
+// treatment (a specific CollaborationMethod/agent roster) arm. Run produces
+// the raw text output for one prompt.
+type Arm struct {
+	Name string
+	Run  func(ctx context.Context, prompt string) (string, error)
+}
+
+// Replicate is one (prompt, arm) execution: its raw output, Judge score,
+// and cost. Err is set if Run or Judge.Score failed, in which case Score/
+// Tokens/Duration are not meaningful and the replicate is excluded from
+// ArmResult's summary stats and from Experiment's comparisons.
+type Replicate struct {
+	Prompt   string
+	Output   string
+	Score    float64
+	Tokens   int
+	Duration time.Duration
+	Err      error
+}
+
+// ArmResult aggregates every Replicate collected for one Arm across an
+// Experiment's prompt corpus.
+type ArmResult struct {
+	Name        string
+	Replicates  []Replicate
+	MeanScore   float64
+	Variance    float64
+	MeanTokens  float64
+	MeanLatency time.Duration
+}
+
+// Comparison is one pairwise arm comparison's significance test and effect
+// size.
+type Comparison struct {
+	ArmA, ArmB   string
+	MannWhitneyU float64
+	PValue       float64
+	CliffsDelta  float64
+}
+
+// Report is what Experiment.Run produces: every arm's summary stats plus
+// every pairwise Comparison, letting a caller rank CollaborationMethod
+// variants by mean score, variance, and cost (tokens/time). See
+// RenderRanking.
+type Report struct {
+	Arms        []ArmResult
+	Comparisons []Comparison
+}
+
+// Experiment runs len(Prompts) x len(Arms) x Replicates generations, scores
+// each with Judge, and compares every pair of arms.
+type Experiment struct {
+	Arms    []Arm
+	Prompts []string
+	// Replicates is how many times each (prompt, arm) pair is run. <= 0
+	// means 1.
+	Replicates int
+	Judge      Judge
+	// Paired, if true, compares arms prompt-by-prompt - appropriate when the
+	// same prompt corpus went through every arm - instead of pooling each
+	// arm's replicates into an unordered sample. A paired design gives
+	// MannWhitneyU/CliffsDelta more power for the same Replicates, since it
+	// controls for per-prompt difficulty.
+	Paired bool
+}
+
+// Run executes the experiment and returns a Report.
+func (e *Experiment) Run(ctx context.Context) (*Report, error) {
+	if e.Judge == nil {
+		return nil, fmt.Errorf("analyzer: Experiment.Judge must be set")
+	}
+	replicates := e.Replicates
+	if replicates <= 0 {
+		replicates = 1
+	}
+
+	armResults := make([]ArmResult, len(e.Arms))
+	for i, arm := range e.Arms {
+		armResults[i].Name = arm.Name
+		for _, prompt := range e.Prompts {
+			for r := 0; r < replicates; r++ {
+				armResults[i].Replicates = append(armResults[i].Replicates, e.runOne(ctx, arm, prompt))
+			}
+		}
+		summarize(&armResults[i])
+	}
+
+	report := &Report{Arms: armResults}
+	for i := 0; i < len(armResults); i++ {
+		for j := i + 1; j < len(armResults); j++ {
+			report.Comparisons = append(report.Comparisons, e.compare(armResults[i], armResults[j]))
+		}
+	}
+	return report, nil
+}
+
+// runOne runs arm once against prompt, timing it and scoring the output
+// with e.Judge, recording this repo's len/4 token-estimate convention.
+func (e *Experiment) runOne(ctx context.Context, arm Arm, prompt string) Replicate {
+	start := time.Now()
+	output, err := arm.Run(ctx, prompt)
+	rep := Replicate{Prompt: prompt, Output: output, Duration: time.Since(start), Err: err}
+	if err != nil {
+		return rep
+	}
+	rep.Tokens = len(prompt)/4 + len(output)/4
+
+	score, err := e.Judge.Score(ctx, prompt, output)
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+	rep.Score = score
+	return rep
+}
+
+// summarize computes result's mean/variance/cost stats from its successful
+// Replicates in place.
+func summarize(result *ArmResult) {
+	var scores []float64
+	var totalTokens, totalDurationNS float64
+	for _, rep := range result.Replicates {
+		if rep.Err != nil {
+			continue
+		}
+		scores = append(scores, rep.Score)
+		totalTokens += float64(rep.Tokens)
+		totalDurationNS += float64(rep.Duration)
+	}
+	n := float64(len(scores))
+	if n == 0 {
+		return
+	}
+
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	result.MeanScore = sum / n
+
+	var variance float64
+	for _, s := range scores {
+		variance += (s - result.MeanScore) * (s - result.MeanScore)
+	}
+	result.Variance = variance / n
+	result.MeanTokens = totalTokens / n
+	result.MeanLatency = time.Duration(totalDurationNS / n)
+}
+
+// compare runs a MannWhitneyU/CliffsDelta comparison between a and b,
+// pairing by prompt first if e.Paired.
+func (e *Experiment) compare(a, b ArmResult) Comparison {
+	scoresA, scoresB := scoresOf(a), scoresOf(b)
+	if e.Paired {
+		scoresA, scoresB = pairByPrompt(a, b)
+	}
+	u, p := MannWhitneyU(scoresA, scoresB)
+	return Comparison{ArmA: a.Name, ArmB: b.Name, MannWhitneyU: u, PValue: p, CliffsDelta: CliffsDelta(scoresA, scoresB)}
+}
+
+func scoresOf(result ArmResult) []float64 {
+	scores := make([]float64, 0, len(result.Replicates))
+	for _, rep := range result.Replicates {
+		if rep.Err == nil {
+			scores = append(scores, rep.Score)
+		}
+	}
+	return scores
+}
+
+// pairByPrompt aligns a and b's successful replicates by matching Prompt
+// (in encounter order, so repeated replicates of the same prompt pair up
+// 1st-with-1st, 2nd-with-2nd), for a paired comparison where the same
+// prompt corpus went through both arms. A prompt missing from either arm
+// (e.g. due to an error) is dropped from both.
+func pairByPrompt(a, b ArmResult) ([]float64, []float64) {
+	byPromptB := make(map[string][]float64)
+	for _, rep := range b.Replicates {
+		if rep.Err == nil {
+			byPromptB[rep.Prompt] = append(byPromptB[rep.Prompt], rep.Score)
+		}
+	}
+
+	consumed := make(map[string]int)
+	var pairedA, pairedB []float64
+	for _, rep := range a.Replicates {
+		if rep.Err != nil {
+			continue
+		}
+		candidates := byPromptB[rep.Prompt]
+		idx := consumed[rep.Prompt]
+		if idx >= len(candidates) {
+			continue
+		}
+		pairedA = append(pairedA, rep.Score)
+		pairedB = append(pairedB, candidates[idx])
+		consumed[rep.Prompt] = idx + 1
+	}
+	return pairedA, pairedB
+}
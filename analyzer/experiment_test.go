@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func lengthJudge() Judge {
+	return RubricJudge(func(_, response string) float64 { return float64(len(response)) })
+}
+
+func TestExperiment_Run(t *testing.T) {
+	exp := &Experiment{
+		Arms: []Arm{
+			{Name: "short", Run: func(_ context.Context, prompt string) (string, error) { return "hi", nil }},
+			{Name: "long", Run: func(_ context.Context, prompt string) (string, error) { return "a much longer response here", nil }},
+		},
+		Prompts:    []string{"p1", "p2"},
+		Replicates: 2,
+		Judge:      lengthJudge(),
+		Paired:     true,
+	}
+
+	report, err := exp.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Arms) != 2 {
+		t.Fatalf("Expected 2 arms, got %d", len(report.Arms))
+	}
+	if len(report.Comparisons) != 1 {
+		t.Fatalf("Expected 1 pairwise comparison, got %d", len(report.Comparisons))
+	}
+
+	var short, long ArmResult
+	for _, arm := range report.Arms {
+		if arm.Name == "short" {
+			short = arm
+		} else {
+			long = arm
+		}
+	}
+	if short.MeanScore >= long.MeanScore {
+		t.Errorf("Expected the long arm to score higher, got short=%f long=%f", short.MeanScore, long.MeanScore)
+	}
+	if report.Comparisons[0].CliffsDelta >= 0 {
+		t.Errorf("Expected a negative CliffsDelta (short scores lower than long), got %f", report.Comparisons[0].CliffsDelta)
+	}
+}
+
+func TestExperiment_RunError(t *testing.T) {
+	exp := &Experiment{
+		Arms: []Arm{
+			{Name: "flaky", Run: func(_ context.Context, prompt string) (string, error) { return "", errors.New("boom") }},
+		},
+		Prompts: []string{"p1"},
+		Judge:   lengthJudge(),
+	}
+	report, err := exp.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.Arms[0].Replicates[0].Err == nil {
+		t.Error("Expected the failed replicate to record its error")
+	}
+	if report.Arms[0].MeanScore != 0 {
+		t.Errorf("Expected a failed replicate to be excluded from MeanScore, got %f", report.Arms[0].MeanScore)
+	}
+}
+
+func TestExperiment_NoJudge(t *testing.T) {
+	exp := &Experiment{Arms: []Arm{{Name: "a", Run: func(context.Context, string) (string, error) { return "", nil }}}, Prompts: []string{"p"}}
+	if _, err := exp.Run(context.Background()); err == nil {
+		t.Error("Expected Experiment.Run to error without a Judge")
+	}
+}
+
+func TestRenderRanking(t *testing.T) {
+	report := &Report{
+		Arms: []ArmResult{
+			{Name: "Alpha", MeanScore: 5},
+			{Name: "Beta", MeanScore: 9},
+		},
+		Comparisons: []Comparison{{ArmA: "Alpha", ArmB: "Beta", CliffsDelta: -0.9, PValue: 0.01}},
+	}
+	out := RenderRanking(report)
+	if idxBeta, idxAlpha := indexOf(out, "Beta"), indexOf(out, "Alpha"); idxBeta > idxAlpha {
+		t.Errorf("Expected the higher-scoring arm Beta to be ranked first, got:\n%s", out)
+	}
+}
+
+func indexOf(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+// Package analyzer treats a CollaborationMethod run as one arm of an
+// experiment against a single-LLM control, scoring each arm's output with a
+// pluggable Judge and comparing arms with nonparametric statistics (see
+// MannWhitneyU, CliffsDelta, BootstrapCI) instead of assuming judge scores
+// are normally distributed.
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/user/breeze"
+)
+
+// Judge scores response (given the prompt that produced it) as a float;
+// Experiment treats higher as better but doesn't otherwise interpret the
+// scale, since MannWhitneyU and CliffsDelta are both scale-invariant.
+type Judge interface {
+	Score(ctx context.Context, prompt, response string) (float64, error)
+}
+
+// LLMJudge is a Judge backed by breeze.AI: it asks the model to rate
+// response against prompt on a 0-10 scale per Rubric, then parses the first
+// number out of the reply.
+type LLMJudge struct {
+	// Rubric describes what "good" means, inserted into the judging prompt
+	// verbatim. Empty means "correctness, clarity, and completeness".
+	Rubric string
+}
+
+var judgeScorePattern = regexp.MustCompile(`\d+(\.\d+)?`)
+
+// Score implements Judge.
+func (j LLMJudge) Score(ctx context.Context, prompt, response string) (float64, error) {
+	rubric := j.Rubric
+	if rubric == "" {
+		rubric = "correctness, clarity, and completeness"
+	}
+	judgePrompt := fmt.Sprintf(
+		"Rate the following response to a prompt on a scale of 0 to 10, judging by %s. Reply with only the number.\n\nPROMPT:\n%s\n\nRESPONSE:\n%s",
+		rubric, prompt, response)
+	reply := breeze.AI(judgePrompt, breeze.WithCtx(ctx), breeze.WithConcise())
+
+	match := judgeScorePattern.FindString(reply)
+	if match == "" {
+		return 0, fmt.Errorf("analyzer: LLMJudge couldn't parse a score out of %q", reply)
+	}
+	return strconv.ParseFloat(match, 64)
+}
+
+// RubricJudge is a Judge backed by a cheap, deterministic scoring function
+// instead of an LLM call - e.g. response length, keyword presence, a regex
+// match - for rubrics that don't need a model to evaluate.
+type RubricJudge func(prompt, response string) float64
+
+// Score implements Judge.
+func (f RubricJudge) Score(_ context.Context, prompt, response string) (float64, error) {
+	return f(prompt, response), nil
+}
+
+// CachingJudge wraps another Judge, memoizing Score by a hash of
+// (prompt, response) so re-running an Experiment against the same corpus
+// doesn't re-invoke an LLM judge for output it has already scored.
+type CachingJudge struct {
+	Judge Judge
+
+	mu    sync.Mutex
+	cache map[string]float64
+}
+
+// NewCachingJudge wraps judge with an empty cache.
+func NewCachingJudge(judge Judge) *CachingJudge {
+	return &CachingJudge{Judge: judge, cache: make(map[string]float64)}
+}
+
+// Score implements Judge.
+func (c *CachingJudge) Score(ctx context.Context, prompt, response string) (float64, error) {
+	key := scoreCacheKey(prompt, response)
+
+	c.mu.Lock()
+	score, cached := c.cache[key]
+	c.mu.Unlock()
+	if cached {
+		return score, nil
+	}
+
+	score, err := c.Judge.Score(ctx, prompt, response)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = score
+	c.mu.Unlock()
+	return score, nil
+}
+
+func scoreCacheKey(prompt, response string) string {
+	sum := sha256.Sum256([]byte(prompt + "\x00" + response))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRubricJudge(t *testing.T) {
+	judge := RubricJudge(func(prompt, response string) float64 { return float64(len(response)) })
+	score, err := judge.Score(context.Background(), "p", "hello")
+	if err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if score != 5 {
+		t.Errorf("Expected score 5, got %f", score)
+	}
+}
+
+func TestCachingJudge_CachesByPromptAndResponse(t *testing.T) {
+	calls := 0
+	inner := RubricJudge(func(prompt, response string) float64 {
+		calls++
+		return float64(len(response))
+	})
+	cached := NewCachingJudge(inner)
+
+	for i := 0; i < 3; i++ {
+		score, err := cached.Score(context.Background(), "p", "hello")
+		if err != nil {
+			t.Fatalf("Score failed: %v", err)
+		}
+		if score != 5 {
+			t.Errorf("Expected score 5, got %f", score)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the inner Judge to be invoked once, got %d calls", calls)
+	}
+
+	if _, err := cached.Score(context.Background(), "p", "a different response"); err != nil {
+		t.Fatalf("Score failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a different response to miss the cache, got %d calls", calls)
+	}
+}
+
+func TestCachingJudge_PropagatesError(t *testing.T) {
+	inner := RubricJudge(func(string, string) float64 { return 0 })
+	cached := &CachingJudge{Judge: failingJudge{}, cache: map[string]float64{}}
+	_ = inner
+	if _, err := cached.Score(context.Background(), "p", "r"); err == nil {
+		t.Error("Expected a failing inner Judge's error to propagate")
+	}
+}
+
+type failingJudge struct{}
+
+func (failingJudge) Score(context.Context, string, string) (float64, error) {
+	return 0, errors.New("judge unavailable")
+}
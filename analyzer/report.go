@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RenderRanking formats report as a human-readable table: arms ranked by
+// mean score (descending) alongside variance and cost, followed by every
+// pairwise Comparison's significance test and effect size - the shape a
+// caller reads to pick, say, PeerReview(2) vs Consensus(3) for a workload.
+func RenderRanking(report *Report) string {
+	ranked := append([]ArmResult(nil), report.Arms...)
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].MeanScore > ranked[j].MeanScore })
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "ARM                  MEAN SCORE  VARIANCE   MEAN TOKENS  MEAN LATENCY")
+	for _, arm := range ranked {
+		fmt.Fprintf(&b, "%-20s %-11.3f %-10.3f %-12.1f %s\n", arm.Name, arm.MeanScore, arm.Variance, arm.MeanTokens, arm.MeanLatency)
+	}
+
+	if len(report.Comparisons) > 0 {
+		fmt.Fprintln(&b, "\nPAIRWISE COMPARISONS")
+		for _, cmp := range report.Comparisons {
+			fmt.Fprintf(&b, "%s vs %s: U=%.1f p=%.4f delta=%.3f (%s)\n",
+				cmp.ArmA, cmp.ArmB, cmp.MannWhitneyU, cmp.PValue, cmp.CliffsDelta, effectSizeLabel(cmp.CliffsDelta))
+		}
+	}
+	return b.String()
+}
+
+// effectSizeLabel names Cliff's delta magnitude using the conventional
+// Romano et al. thresholds.
+func effectSizeLabel(delta float64) string {
+	abs := delta
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 0.147:
+		return "negligible"
+	case abs < 0.33:
+		return "small"
+	case abs < 0.474:
+		return "medium"
+	default:
+		return "large"
+	}
+}
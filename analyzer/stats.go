@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// MannWhitneyU computes the Mann-Whitney U statistic for a vs b (the
+// smaller of Ua/Ub) and a two-sided p-value via the normal approximation
+// with a tie correction, the standard nonparametric alternative to a
+// t-test when scores aren't assumed normally distributed (LLM judge scores
+// rarely are). An empty a or b returns (0, 1) - no evidence of a
+// difference.
+func MannWhitneyU(a, b []float64) (u float64, pValue float64) {
+	na, nb := len(a), len(b)
+	if na == 0 || nb == 0 {
+		return 0, 1
+	}
+
+	type sample struct {
+		value float64
+		fromA bool
+	}
+	all := make([]sample, 0, na+nb)
+	for _, v := range a {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range b {
+		all = append(all, sample{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	ranks := make([]float64, len(all))
+	var tieSum float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		avgRank := float64(i+j+1) / 2 // 1-based average rank across the tie block
+		for k := i; k < j; k++ {
+			ranks[k] = avgRank
+		}
+		t := float64(j - i)
+		tieSum += t*t*t - t
+		i = j
+	}
+
+	var rankSumA float64
+	for i, s := range all {
+		if s.fromA {
+			rankSumA += ranks[i]
+		}
+	}
+
+	ua := rankSumA - float64(na*(na+1))/2
+	ub := float64(na*nb) - ua
+	u = math.Min(ua, ub)
+
+	n := float64(na + nb)
+	meanU := float64(na*nb) / 2
+	stdU := math.Sqrt(float64(na*nb) / 12 * ((n + 1) - tieSum/(n*(n-1))))
+	if stdU == 0 {
+		return u, 1
+	}
+
+	z := (u - meanU) / stdU
+	p := 2 * (1 - normalCDF(math.Abs(z)))
+	if p > 1 {
+		p = 1
+	}
+	return u, p
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// CliffsDelta computes Cliff's delta, a nonparametric effect size in
+// [-1, 1] for how much a tends to score higher (positive) or lower
+// (negative) than b; 0 means no tendency either way. Conventionally (Romano
+// et al.) |delta| < 0.147 is "negligible", < 0.33 "small", < 0.474
+// "medium", else "large".
+func CliffsDelta(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	var more, less int
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case x > y:
+				more++
+			case x < y:
+				less++
+			}
+		}
+	}
+	return float64(more-less) / float64(len(a)*len(b))
+}
+
+// BootstrapCI returns a (1-alpha) percentile-bootstrap confidence interval
+// for the mean of samples, resampling resamples times (>=1000
+// recommended). A nil/empty samples or resamples <= 0 (defaulted to 1000)
+// still returns a valid interval; an empty samples returns (0, 0).
+func BootstrapCI(samples []float64, resamples int, alpha float64) (lo, hi float64) {
+	n := len(samples)
+	if n == 0 {
+		return 0, 0
+	}
+	if resamples <= 0 {
+		resamples = 1000
+	}
+
+	means := make([]float64, resamples)
+	for i := 0; i < resamples; i++ {
+		var sum float64
+		for j := 0; j < n; j++ {
+			sum += samples[rand.Intn(n)]
+		}
+		means[i] = sum / float64(n)
+	}
+	sort.Float64s(means)
+
+	loIdx := int(alpha / 2 * float64(resamples))
+	hiIdx := int((1 - alpha/2) * float64(resamples))
+	if hiIdx >= resamples {
+		hiIdx = resamples - 1
+	}
+	return means[loIdx], means[hiIdx]
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMannWhitneyU_IdenticalSamples(t *testing.T) {
+	a := []float64{1, 2, 3, 4, 5}
+	_, p := MannWhitneyU(a, a)
+	if p < 0.9 {
+		t.Errorf("Expected identical samples to show no significant difference, got p=%f", p)
+	}
+}
+
+func TestMannWhitneyU_ClearlySeparated(t *testing.T) {
+	a := []float64{9, 9, 9, 9, 9}
+	b := []float64{1, 1, 1, 1, 1}
+	_, p := MannWhitneyU(a, b)
+	if p > 0.05 {
+		t.Errorf("Expected clearly separated samples to be significant, got p=%f", p)
+	}
+}
+
+func TestMannWhitneyU_EmptyInput(t *testing.T) {
+	if u, p := MannWhitneyU(nil, []float64{1, 2}); u != 0 || p != 1 {
+		t.Errorf("Expected (0, 1) for an empty sample, got (%f, %f)", u, p)
+	}
+}
+
+func TestCliffsDelta(t *testing.T) {
+	a := []float64{9, 9, 9}
+	b := []float64{1, 1, 1}
+	if delta := CliffsDelta(a, b); delta != 1 {
+		t.Errorf("Expected CliffsDelta to be 1 for a fully-separated pair, got %f", delta)
+	}
+	if delta := CliffsDelta(b, a); delta != -1 {
+		t.Errorf("Expected CliffsDelta to be -1 when reversed, got %f", delta)
+	}
+	if delta := CliffsDelta(a, a); delta != 0 {
+		t.Errorf("Expected CliffsDelta of identical samples to be 0, got %f", delta)
+	}
+	if delta := CliffsDelta(nil, a); delta != 0 {
+		t.Errorf("Expected CliffsDelta of an empty sample to be 0, got %f", delta)
+	}
+}
+
+func TestBootstrapCI(t *testing.T) {
+	samples := []float64{1, 2, 3, 4, 5}
+	lo, hi := BootstrapCI(samples, 1000, 0.05)
+	if lo > 3 || hi < 3 {
+		t.Errorf("Expected the 95%% CI to bracket the sample mean (3), got [%f, %f]", lo, hi)
+	}
+	if lo, hi := BootstrapCI(nil, 1000, 0.05); lo != 0 || hi != 0 {
+		t.Errorf("Expected (0, 0) for an empty sample, got (%f, %f)", lo, hi)
+	}
+}
+
+func TestNormalCDF(t *testing.T) {
+	if math.Abs(normalCDF(0)-0.5) > 0.001 {
+		t.Errorf("Expected normalCDF(0) ~= 0.5, got %f", normalCDF(0))
+	}
+}
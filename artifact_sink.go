@@ -0,0 +1,199 @@
+package breeze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PhaseArtifact is the structured record an ArtifactSink receives after one
+// TeamCollaboration phase completes - the same information OnPhaseComplete
+// gets as a flat map, plus timing, a token estimate, and a SharedKnowledge
+// snapshot for sinks that want to diff across phases.
+type PhaseArtifact struct {
+	PhaseName string
+	// TeamResults is keyed by team name, then agent name, mirroring how
+	// runTeamPhase groups a phase's work.
+	TeamResults map[string]map[string]string
+	Duration    time.Duration
+	// TokensUsed is an estimate (len(response)/4, the same convention used
+	// elsewhere in this package) summed across every agent in the phase.
+	TokensUsed int
+	// Errors lists anything that went wrong producing this phase's results.
+	// TeamCollaboration has no per-agent error path today, so this is
+	// currently always empty; it's here so a sink's schema doesn't need to
+	// change once one exists.
+	Errors []string
+	// SharedKnowledge is a snapshot of TeamCollaboration.SharedKnowledge
+	// immediately after this phase, letting a sink diff it against the
+	// previous phase's snapshot.
+	SharedKnowledge map[string]string
+}
+
+// ArtifactSink receives a PhaseArtifact after every TeamCollaboration phase
+// completes. See MarkdownSummarySink, JSONLSink, HTTPSink.
+type ArtifactSink interface {
+	EmitPhase(artifact PhaseArtifact) error
+}
+
+// markdownSink renders an Actions-style step summary: one collapsible
+// section per phase with each agent's contribution, plus a diff of
+// SharedKnowledge against the previous phase.
+type markdownSink struct {
+	mu        sync.Mutex
+	path      string
+	prevKnown map[string]string
+	started   bool
+}
+
+// MarkdownSummarySink returns an ArtifactSink that appends a rendered
+// Markdown document to path - truncating it on the first phase of a run,
+// then appending one collapsible <details> section per subsequent phase -
+// so a completed run leaves behind a browsable report instead of only
+// stdout emojis from OnPhaseComplete.
+func MarkdownSummarySink(path string) ArtifactSink {
+	return &markdownSink{path: path}
+}
+
+// EmitPhase implements ArtifactSink.
+func (s *markdownSink) EmitPhase(artifact PhaseArtifact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !s.started {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	file, err := os.OpenFile(s.path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("markdown summary sink: open %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	var b strings.Builder
+	if !s.started {
+		fmt.Fprintln(&b, "# Team Collaboration Summary")
+		s.started = true
+	}
+
+	fmt.Fprintf(&b, "\n<details>\n<summary>Phase: %s (%s, ~%d tokens)</summary>\n\n",
+		artifact.PhaseName, artifact.Duration.Round(1e6), artifact.TokensUsed)
+
+	teamNames := make([]string, 0, len(artifact.TeamResults))
+	for name := range artifact.TeamResults {
+		teamNames = append(teamNames, name)
+	}
+	sort.Strings(teamNames)
+	for _, teamName := range teamNames {
+		fmt.Fprintf(&b, "### %s\n\n", teamName)
+		agentNames := make([]string, 0, len(artifact.TeamResults[teamName]))
+		for name := range artifact.TeamResults[teamName] {
+			agentNames = append(agentNames, name)
+		}
+		sort.Strings(agentNames)
+		for _, agentName := range agentNames {
+			fmt.Fprintf(&b, "**%s**\n\n%s\n\n", agentName, artifact.TeamResults[teamName][agentName])
+		}
+	}
+
+	if diff := sharedKnowledgeDiff(s.prevKnown, artifact.SharedKnowledge); diff != "" {
+		fmt.Fprintf(&b, "#### SharedKnowledge changes\n\n%s\n", diff)
+	}
+	if len(artifact.Errors) > 0 {
+		fmt.Fprintf(&b, "#### Errors\n\n- %s\n", strings.Join(artifact.Errors, "\n- "))
+	}
+	fmt.Fprintln(&b, "\n</details>")
+
+	s.prevKnown = artifact.SharedKnowledge
+
+	_, err = file.WriteString(b.String())
+	return err
+}
+
+// sharedKnowledgeDiff renders the keys that are new or changed in next
+// relative to prev as a Markdown bullet list, or "" if nothing changed.
+func sharedKnowledgeDiff(prev, next map[string]string) string {
+	var names []string
+	for name, value := range next {
+		if prevValue, ok := prev[name]; !ok || prevValue != value {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "- **%s** updated\n", name)
+	}
+	return b.String()
+}
+
+// jsonlSink appends one JSON-encoded PhaseArtifact per line to a file, the
+// same shape FileConversationStore uses for its append-only message log.
+type jsonlSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// JSONLSink returns an ArtifactSink that appends each PhaseArtifact as a
+// single JSON line to path, for tooling that wants to stream-process a run
+// rather than parse a rendered Markdown report.
+func JSONLSink(path string) ArtifactSink {
+	return &jsonlSink{path: path}
+}
+
+// EmitPhase implements ArtifactSink.
+func (s *jsonlSink) EmitPhase(artifact PhaseArtifact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return fmt.Errorf("jsonl sink: marshal phase %s: %w", artifact.PhaseName, err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("jsonl sink: open %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// httpSink POSTs each PhaseArtifact as JSON to a fixed URL.
+type httpSink struct {
+	url string
+}
+
+// HTTPSink returns an ArtifactSink that POSTs each PhaseArtifact as JSON to
+// url, for forwarding a run's progress to an external dashboard or webhook.
+func HTTPSink(url string) ArtifactSink {
+	return &httpSink{url: url}
+}
+
+// EmitPhase implements ArtifactSink.
+func (s *httpSink) EmitPhase(artifact PhaseArtifact) error {
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return fmt.Errorf("http sink: marshal phase %s: %w", artifact.PhaseName, err)
+	}
+	resp, err := http.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("http sink: post to %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("http sink: %s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package breeze
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSharedKnowledgeDiff(t *testing.T) {
+	prev := map[string]string{"SW": "use Go"}
+	next := map[string]string{"SW": "use Go", "QA": "add tests"}
+	diff := sharedKnowledgeDiff(prev, next)
+	if !strings.Contains(diff, "QA") || strings.Contains(diff, "**SW**") {
+		t.Errorf("Expected diff to mention only the changed key QA, got %q", diff)
+	}
+
+	if diff := sharedKnowledgeDiff(next, next); diff != "" {
+		t.Errorf("Expected no diff for identical snapshots, got %q", diff)
+	}
+}
+
+func TestMarkdownSummarySink_EmitPhase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	sink := MarkdownSummarySink(path)
+
+	err := sink.EmitPhase(PhaseArtifact{
+		PhaseName:       "Requirements Analysis",
+		TeamResults:     map[string]map[string]string{"SW Engineering": {"Alice": "Use Go."}},
+		TokensUsed:      3,
+		SharedKnowledge: map[string]string{"Alice": "Use Go."},
+	})
+	if err != nil {
+		t.Fatalf("EmitPhase failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summary file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"# Team Collaboration Summary", "Requirements Analysis", "Alice", "Use Go.", "<details>"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestJSONLSink_EmitPhase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phases.jsonl")
+	sink := JSONLSink(path)
+
+	if err := sink.EmitPhase(PhaseArtifact{PhaseName: "Requirements Analysis", TokensUsed: 10}); err != nil {
+		t.Fatalf("EmitPhase failed: %v", err)
+	}
+	if err := sink.EmitPhase(PhaseArtifact{PhaseName: "SW Implementation", TokensUsed: 20}); err != nil {
+		t.Fatalf("EmitPhase failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read jsonl file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), lines)
+	}
+	var artifact PhaseArtifact
+	if err := json.Unmarshal([]byte(lines[0]), &artifact); err != nil {
+		t.Fatalf("Failed to unmarshal line: %v", err)
+	}
+	if artifact.PhaseName != "Requirements Analysis" || artifact.TokensUsed != 10 {
+		t.Errorf("Unexpected artifact: %+v", artifact)
+	}
+}
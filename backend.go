@@ -0,0 +1,317 @@
+package breeze
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Backend is a pluggable generation engine. The default AI/Chat/Code helpers
+// talk to Ollama directly, but a Backend lets callers (or individual Agents)
+// swap in a different runtime, such as a locally-spawned llama.cpp process.
+type Backend interface {
+	Generate(ctx context.Context, messages []Message, opts RequestOptions) (string, error)
+	GenerateStream(ctx context.Context, messages []Message, opts RequestOptions) (<-chan Token, func() error)
+}
+
+// defaultBackend is the package-level backend used when an Agent does not
+// override it. It is nil until SetBackend is called, meaning "use Ollama".
+var defaultBackend Backend
+
+// SetBackend installs b as the package-level default backend.
+func SetBackend(b Backend) {
+	defaultBackend = b
+}
+
+// BackendStats reports resident memory and CPU usage sampled from a running
+// backend's child process. CPUPercent is averaged over the time since the
+// previous Stats() call (0 on the first call, since there's no prior sample
+// to measure a delta against).
+type BackendStats struct {
+	PID        int
+	RSSBytes   int64
+	CPUPercent float64
+}
+
+// cpuSample is the process CPU-time reading Stats needs to compute a
+// CPUPercent delta across two calls.
+type cpuSample struct {
+	at    time.Time
+	ticks int64
+}
+
+// LlamaCppBackend spawns a local llama.cpp `main`/`llama-server` binary per
+// generation request, feeding it the rendered prompt on stdin (or via -p) and
+// reading its stdout through a pipe. A crashed process is restarted, up to
+// MaxRestarts times, on the next Generate/GenerateStream call rather than
+// failing every call from then on.
+type LlamaCppBackend struct {
+	// BinPath is the path to the llama.cpp executable (e.g. "main" or
+	// "llama-server"). Defaults to "llama-cli" if empty.
+	BinPath string
+	// ModelPath is the path to the gguf model file (-m).
+	ModelPath   string
+	NGL         int // number of layers offloaded to GPU (-ngl)
+	CtxSize     int // context window size (-c)
+	Temperature float64
+	NPredict    int // max tokens to generate (-n)
+	// MaxRestarts is how many times Generate/GenerateStream will respawn
+	// the binary after it exits non-zero before giving up and returning the
+	// error. A context cancellation is never retried. Defaults to 2.
+	MaxRestarts int
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	started bool
+	lastCPU cpuSample
+}
+
+// NewLlamaCppBackend creates a backend that will spawn binPath with the given
+// model on each call.
+func NewLlamaCppBackend(binPath, modelPath string) *LlamaCppBackend {
+	return &LlamaCppBackend{
+		BinPath:     binPath,
+		ModelPath:   modelPath,
+		NGL:         0,
+		CtxSize:     2048,
+		Temperature: 0.7,
+		NPredict:    512,
+		MaxRestarts: 2,
+	}
+}
+
+// args builds the llama.cpp CLI argument list for a single generation.
+func (l *LlamaCppBackend) args(prompt string, opts RequestOptions) []string {
+	temp := l.Temperature
+	if opts.Temp != 0 {
+		temp = opts.Temp
+	}
+	a := []string{
+		"-m", l.ModelPath,
+		"-p", prompt,
+		"-ngl", strconv.Itoa(l.NGL),
+		"-c", strconv.Itoa(l.CtxSize),
+		"-n", strconv.Itoa(l.NPredict),
+		"--temp", strconv.FormatFloat(temp, 'f', -1, 64),
+		"--simple-io",
+		"-no-cnv",
+	}
+	if opts.Grammar != "" {
+		a = append(a, "--grammar", opts.Grammar)
+	}
+	return a
+}
+
+// renderPrompt turns a message list into a flat prompt, since llama.cpp's
+// plain `main` binary has no native chat-message API.
+func renderPrompt(messages []Message) string {
+	var b strings.Builder
+	for _, m := range messages {
+		b.WriteString(strings.ToUpper(m.Role))
+		b.WriteString(": ")
+		b.WriteString(m.Content)
+		b.WriteString("\n")
+	}
+	b.WriteString("ASSISTANT: ")
+	return b.String()
+}
+
+func (l *LlamaCppBackend) binPath() string {
+	if l.BinPath != "" {
+		return l.BinPath
+	}
+	return "llama-cli"
+}
+
+// Generate runs the llama.cpp binary to completion and returns its stdout,
+// restarting it up to MaxRestarts times if it crashes (exits non-zero
+// without ctx having been canceled) before giving up.
+func (l *LlamaCppBackend) Generate(ctx context.Context, messages []Message, opts RequestOptions) (string, error) {
+	prompt := renderPrompt(messages)
+
+	var lastErr error
+	for attempt := 0; attempt <= l.MaxRestarts; attempt++ {
+		cmd := exec.CommandContext(ctx, l.binPath(), l.args(prompt, opts)...)
+
+		l.mu.Lock()
+		l.cmd = cmd
+		l.started = true
+		l.mu.Unlock()
+
+		out, err := cmd.Output()
+		if err == nil {
+			return strings.TrimPrefix(string(out), prompt), nil
+		}
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("llama.cpp generate failed: %w", err)
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("llama.cpp generate failed after %d restart(s): %w", l.MaxRestarts, lastErr)
+}
+
+// GenerateStream runs the llama.cpp binary and streams its stdout token by
+// token (here, whitespace-delimited chunks, since the plain CLI does not
+// emit a structured event stream), restarting it up to MaxRestarts times -
+// from the beginning of the prompt, so a caller sees TokensSoFar reset to 1 -
+// if it crashes before ctx is canceled.
+func (l *LlamaCppBackend) GenerateStream(ctx context.Context, messages []Message, opts RequestOptions) (<-chan Token, func() error) {
+	prompt := renderPrompt(messages)
+
+	tokens := make(chan Token)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(tokens)
+
+		var lastErr error
+		for attempt := 0; attempt <= l.MaxRestarts; attempt++ {
+			err := l.streamOnce(ctx, prompt, opts, tokens)
+			if err == nil {
+				errCh <- nil
+				return
+			}
+			if ctx.Err() != nil {
+				errCh <- err
+				return
+			}
+			lastErr = err
+		}
+		errCh <- fmt.Errorf("llama.cpp generate stream failed after %d restart(s): %w", l.MaxRestarts, lastErr)
+	}()
+
+	return tokens, func() error { return <-errCh }
+}
+
+// streamOnce spawns a single llama.cpp process and forwards its stdout to
+// tokens until it exits, returning the exit error (if any) so
+// GenerateStream can decide whether to restart.
+func (l *LlamaCppBackend) streamOnce(ctx context.Context, prompt string, opts RequestOptions, tokens chan<- Token) error {
+	cmd := exec.CommandContext(ctx, l.binPath(), l.args(prompt, opts)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.cmd = cmd
+	l.started = true
+	l.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(bufio.ScanWords)
+	count := 0
+	start := time.Now()
+	for scanner.Scan() {
+		count++
+		tokens <- Token{
+			Text:        scanner.Text() + " ",
+			TokensSoFar: count,
+			ElapsedMs:   time.Since(start).Milliseconds(),
+		}
+	}
+	return cmd.Wait()
+}
+
+// Kill terminates the in-flight llama.cpp process, if any. Intended to be
+// called from a context cancellation so callers don't leak orphaned
+// subprocesses.
+func (l *LlamaCppBackend) Kill() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cmd == nil || l.cmd.Process == nil {
+		return nil
+	}
+	return l.cmd.Process.Kill()
+}
+
+// Stats returns resident memory and CPU usage for the running child process,
+// read from /proc on Linux. Returns an error on platforms without /proc.
+func (l *LlamaCppBackend) Stats() (BackendStats, error) {
+	l.mu.Lock()
+	cmd := l.cmd
+	l.mu.Unlock()
+
+	if cmd == nil || cmd.Process == nil {
+		return BackendStats{}, fmt.Errorf("backend has no running process")
+	}
+
+	pid := cmd.Process.Pid
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return BackendStats{}, fmt.Errorf("read process stats: %w", err)
+	}
+
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return BackendStats{}, fmt.Errorf("unexpected statm format")
+	}
+	resident, _ := strconv.ParseInt(fields[1], 10, 64)
+	pageSize := int64(4096)
+
+	cpuPercent, err := l.sampleCPUPercent(pid)
+	if err != nil {
+		return BackendStats{}, err
+	}
+
+	return BackendStats{
+		PID:        pid,
+		RSSBytes:   resident * pageSize,
+		CPUPercent: cpuPercent,
+	}, nil
+}
+
+// sampleCPUPercent reads pid's accumulated utime+stime (in clock ticks) from
+// /proc/pid/stat and turns it into a percentage by comparing against the
+// ticks/timestamp Stats recorded on its previous call for this backend -
+// returning 0, nil on the first call, since there's no prior sample to diff
+// against. clockTicksPerSec is Linux's USER_HZ, 100 on every platform this
+// targets.
+func (l *LlamaCppBackend) sampleCPUPercent(pid int) (float64, error) {
+	const clockTicksPerSec = 100
+
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, fmt.Errorf("read process stat: %w", err)
+	}
+
+	// Fields up to and including the "(comm)" field are skipped by scanning
+	// past the last ')', since comm itself may contain spaces; utime and
+	// stime are the 14th and 15th whitespace-separated fields overall, i.e.
+	// indices 11 and 12 after that point.
+	afterComm := data[bytes.LastIndexByte(data, ')')+1:]
+	fields := strings.Fields(string(afterComm))
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("unexpected stat format")
+	}
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	ticks := utime + stime
+
+	now := time.Now()
+	l.mu.Lock()
+	prev := l.lastCPU
+	l.lastCPU = cpuSample{at: now, ticks: ticks}
+	l.mu.Unlock()
+
+	if prev.at.IsZero() {
+		return 0, nil
+	}
+	elapsed := now.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return 0, nil
+	}
+	return float64(ticks-prev.ticks) / clockTicksPerSec / elapsed * 100, nil
+}
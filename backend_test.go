@@ -0,0 +1,200 @@
+package breeze
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLlamaCppBackend_Args(t *testing.T) {
+	l := NewLlamaCppBackend("llama-cli", "model.gguf")
+	l.NGL = 35
+	l.CtxSize = 4096
+	l.NPredict = 128
+
+	args := l.args("hello", RequestOptions{Temp: 0.2, Grammar: "root ::= \"yes\""})
+
+	want := []string{"-m", "model.gguf", "-p", "hello", "-ngl", "35", "-c", "4096", "-n", "128", "--temp", "0.2", "--grammar"}
+	for _, w := range want {
+		found := false
+		for _, a := range args {
+			if a == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected args to contain %q, got %v", w, args)
+		}
+	}
+}
+
+func TestLlamaCppBackend_Args_FallsBackToConfiguredTemp(t *testing.T) {
+	l := NewLlamaCppBackend("llama-cli", "model.gguf")
+	l.Temperature = 0.9
+	args := l.args("hello", RequestOptions{})
+
+	for i, a := range args {
+		if a == "--temp" {
+			if i+1 >= len(args) || args[i+1] != "0.9" {
+				t.Errorf("Expected --temp to use LlamaCppBackend.Temperature (0.9) when RequestOptions.Temp is unset, got %v", args)
+			}
+			return
+		}
+	}
+	t.Fatal("Expected args to include --temp")
+}
+
+func TestLlamaCppBackend_BinPathDefaultsToLlamaCli(t *testing.T) {
+	l := NewLlamaCppBackend("", "model.gguf")
+	if got := l.binPath(); got != "llama-cli" {
+		t.Errorf("Expected default binPath llama-cli, got %q", got)
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	prompt := renderPrompt([]Message{
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", Content: "hello"},
+	})
+	if !strings.Contains(prompt, "USER: hi\n") || !strings.Contains(prompt, "ASSISTANT: hello\n") {
+		t.Errorf("Expected rendered prompt to include uppercased roles, got %q", prompt)
+	}
+	if !strings.HasSuffix(prompt, "ASSISTANT: ") {
+		t.Errorf("Expected rendered prompt to end with a trailing ASSISTANT: turn, got %q", prompt)
+	}
+}
+
+// writeCountingScript writes a shell script to dir that increments a
+// counter file on every invocation, exiting 1 until the counter reaches
+// succeedOnAttempt, at which point it prints "ok" and exits 0.
+func writeCountingScript(t *testing.T, dir string, succeedOnAttempt int) (scriptPath, counterPath string) {
+	t.Helper()
+	counterPath = filepath.Join(dir, "count")
+	scriptPath = filepath.Join(dir, "run.sh")
+	script := fmt.Sprintf(`#!/bin/sh
+count_file=%q
+n=0
+if [ -f "$count_file" ]; then
+  n=$(cat "$count_file")
+fi
+n=$((n + 1))
+echo "$n" > "$count_file"
+if [ "$n" -ge %d ]; then
+  echo "ok"
+  exit 0
+fi
+exit 1
+`, counterPath, succeedOnAttempt)
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	return scriptPath, counterPath
+}
+
+func readCount(t *testing.T, counterPath string) int {
+	t.Helper()
+	data, err := os.ReadFile(counterPath)
+	if err != nil {
+		return 0
+	}
+	var n int
+	fmt.Sscanf(strings.TrimSpace(string(data)), "%d", &n)
+	return n
+}
+
+func TestLlamaCppBackend_Generate_RestartsOnCrash(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 2)
+
+	l := NewLlamaCppBackend(script, "model.gguf")
+	l.MaxRestarts = 1
+
+	out, err := l.Generate(context.Background(), []Message{{Role: "user", Content: "hi"}}, RequestOptions{})
+	if err != nil {
+		t.Fatalf("Expected Generate to succeed after one restart, got error: %v", err)
+	}
+	if !strings.Contains(out, "ok") {
+		t.Errorf("Expected output to contain \"ok\", got %q", out)
+	}
+	if got := readCount(t, counter); got != 2 {
+		t.Errorf("Expected the binary to have run twice (1 crash + 1 success), ran %d times", got)
+	}
+}
+
+func TestLlamaCppBackend_Generate_GivesUpAfterMaxRestarts(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 100) // never succeeds within MaxRestarts
+
+	l := NewLlamaCppBackend(script, "model.gguf")
+	l.MaxRestarts = 2
+
+	_, err := l.Generate(context.Background(), []Message{{Role: "user", Content: "hi"}}, RequestOptions{})
+	if err == nil {
+		t.Fatal("Expected Generate to fail once MaxRestarts is exhausted")
+	}
+	if got := readCount(t, counter); got != 3 {
+		t.Errorf("Expected exactly MaxRestarts+1 = 3 attempts, got %d", got)
+	}
+}
+
+func TestLlamaCppBackend_Generate_DoesNotRestartOnContextCancel(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 100)
+
+	l := NewLlamaCppBackend(script, "model.gguf")
+	l.MaxRestarts = 5
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := l.Generate(ctx, []Message{{Role: "user", Content: "hi"}}, RequestOptions{})
+	if err == nil {
+		t.Fatal("Expected Generate to fail for an already-canceled context")
+	}
+	if got := readCount(t, counter); got > 1 {
+		t.Errorf("Expected a canceled context to abort after at most one attempt, ran %d times", got)
+	}
+}
+
+func TestLlamaCppBackend_Stats_NoProcess(t *testing.T) {
+	l := NewLlamaCppBackend("llama-cli", "model.gguf")
+	if _, err := l.Stats(); err == nil {
+		t.Error("Expected Stats to fail when no process has run yet")
+	}
+}
+
+func TestLlamaCppBackend_SampleCPUPercent_FirstCallIsZero(t *testing.T) {
+	l := NewLlamaCppBackend("llama-cli", "model.gguf")
+	percent, err := l.sampleCPUPercent(os.Getpid())
+	if err != nil {
+		t.Fatalf("sampleCPUPercent failed: %v", err)
+	}
+	if percent != 0 {
+		t.Errorf("Expected the first CPU sample to be 0 (no prior reading to diff against), got %v", percent)
+	}
+}
+
+func TestLlamaCppBackend_SampleCPUPercent_SecondCallComputesDelta(t *testing.T) {
+	l := NewLlamaCppBackend("llama-cli", "model.gguf")
+	if _, err := l.sampleCPUPercent(os.Getpid()); err != nil {
+		t.Fatalf("first sampleCPUPercent failed: %v", err)
+	}
+
+	// Burn a little CPU so utime/stime have moved since the first sample.
+	deadline := time.Now().Add(20 * time.Millisecond)
+	for time.Now().Before(deadline) {
+	}
+
+	percent, err := l.sampleCPUPercent(os.Getpid())
+	if err != nil {
+		t.Fatalf("second sampleCPUPercent failed: %v", err)
+	}
+	if percent < 0 {
+		t.Errorf("Expected a non-negative CPU percent, got %v", percent)
+	}
+}
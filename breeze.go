@@ -21,14 +21,16 @@
 package breeze
 
 import (
-	"archive/zip"
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -45,6 +47,10 @@ type Breeze struct {
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// Images are base64-encoded image bytes attached to this message, passed
+	// straight through to providers whose chat API accepts inline images
+	// (e.g. Ollama's vision models).
+	Images []string `json:"images,omitempty"`
 }
 
 // Option is a functional option for configuring requests
@@ -58,6 +64,77 @@ type RequestOptions struct {
 	Context string
 	Docs    []string
 	Concise bool
+	// Provider overrides which LLM vendor handles this request. See
+	// WithProvider and the BREEZE_PROVIDER env var.
+	Provider Provider
+	// Images are attached to the request for providers with vision support
+	// (Ollama llava/llama3.2-vision, OpenAI gpt-4o, Gemini, Claude 3). See
+	// WithImages.
+	Images [][]byte
+	// OCREngine is the fallback text recognizer extractTextFromPDF uses when
+	// a PDF page has no extractable text (e.g. a scan). Nil means
+	// TesseractOCREngine{}. See WithOCR.
+	OCREngine OCREngine
+	// DocFormat forces extractTextFromFile to use a specific parser instead
+	// of sniffing the file's format. See WithDocFormat.
+	DocFormat DocFormat
+	// Tools are callable functions AI/Chat may invoke mid-turn. See
+	// WithTools.
+	Tools []ToolSpec
+	// ToolChoice constrains which tool(s) may be called. See WithToolChoice.
+	ToolChoice ToolChoice
+	// MaxToolIterations bounds the tool-calling loop. Zero means
+	// defaultMaxToolIterations. See WithMaxToolIterations.
+	MaxToolIterations int
+	// ToolObserver is notified of each ToolCall/ToolResult during the
+	// tool-calling loop. See WithToolObserver.
+	ToolObserver func(ToolCall, ToolResult)
+	// JSONSchema, if set, is forwarded as Ollama's "format" field to
+	// constrain decoding to matching JSON. See WithJSONSchema and AIInto.
+	JSONSchema string
+	// Grammar, if set, is a GBNF grammar forwarded to backends that support
+	// grammar-constrained decoding (e.g. LlamaCppBackend's --grammar flag).
+	// See WithGrammar.
+	Grammar string
+	// Ctx governs cancellation of this request's in-flight Ollama HTTP
+	// call. Nil means context.Background(). See WithCtx.
+	Ctx context.Context
+	// RAGStore, if set, turns Docs from "inline the full text" into
+	// retrieval-augmented context: the prompt is embedded, the RAGTopK most
+	// relevant chunks are retrieved from RAGStore, and only those are
+	// injected. See WithRAG and Index.
+	RAGStore VectorStore
+	// RAGTopK is how many chunks WithRAG retrieves. Zero means
+	// defaultRAGTopK.
+	RAGTopK int
+}
+
+// WithCtx attaches ctx to the request so canceling it (e.g. via
+// BatchWithConcurrency/BatchStream) aborts the in-flight Ollama HTTP call.
+// Not to be confused with WithContext, which prepends text to the prompt.
+func WithCtx(ctx context.Context) Option {
+	return func(opts *RequestOptions) {
+		opts.Ctx = ctx
+	}
+}
+
+// reqCtx returns options.Ctx, defaulting to context.Background().
+func reqCtx(options RequestOptions) context.Context {
+	if options.Ctx != nil {
+		return options.Ctx
+	}
+	return context.Background()
+}
+
+// postJSON posts body to url with ctx wired through, so the caller can
+// cancel the in-flight request.
+func postJSON(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return http.DefaultClient.Do(req)
 }
 
 // WithModel sets the model for the request
@@ -88,6 +165,20 @@ func WithDocs(filePaths ...string) Option {
 	}
 }
 
+// WithRAG turns WithDocs into retrieval-augmented context: instead of
+// inlining every document's full text (which blows the context window on
+// anything non-trivial), AI/Chat embed the prompt, retrieve the k most
+// relevant chunks from store, and inject only those. For a large or stable
+// corpus, prefer indexing once with Index and passing WithRAG without
+// WithDocs, since re-indexing on every call duplicates chunks rather than
+// deduplicating them.
+func WithRAG(store VectorStore, k int) Option {
+	return func(opts *RequestOptions) {
+		opts.RAGStore = store
+		opts.RAGTopK = k
+	}
+}
+
 // WithConcise enables concise responses with streaming output
 func WithConcise() Option {
 	return func(opts *RequestOptions) {
@@ -96,6 +187,22 @@ func WithConcise() Option {
 	}
 }
 
+// WithImages attaches images (e.g. a screenshot or a whiteboard photo) to the
+// request, reading each path from disk. Vision-capable providers (Ollama
+// llava/llama3.2-vision, OpenAI gpt-4o, Gemini, Claude 3) attach them in
+// their own native way; unreadable paths are skipped.
+func WithImages(paths ...string) Option {
+	return func(opts *RequestOptions) {
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			opts.Images = append(opts.Images, data)
+		}
+	}
+}
+
 // preferredModels in order of preference
 var preferredModels = []string{"gpt-oss", "codellama", "llama2", "mistral"}
 
@@ -133,138 +240,17 @@ func ensureOllamaRunning() {
 	}
 }
 
-// extractTextFromFile extracts text content from various file formats
-func extractTextFromFile(filePath string) (string, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file %s: %v", filePath, err)
-	}
-
-	// Determine file type by extension
-	if strings.HasSuffix(strings.ToLower(filePath), ".txt") {
-		return string(data), nil
+// encodeImages base64-encodes raw image bytes for APIs (like Ollama's) that
+// take images as a list of base64 strings.
+func encodeImages(images [][]byte) []string {
+	if len(images) == 0 {
+		return nil
 	}
-
-	if strings.HasSuffix(strings.ToLower(filePath), ".pdf") {
-		return extractTextFromPDF(data)
-	}
-
-	if strings.HasSuffix(strings.ToLower(filePath), ".docx") {
-		return extractTextFromDOCX(data)
+	encoded := make([]string, len(images))
+	for i, img := range images {
+		encoded[i] = base64.StdEncoding.EncodeToString(img)
 	}
-
-	return "", fmt.Errorf("unsupported file format: %s", filePath)
-}
-
-// extractTextFromPDF extracts text from PDF files
-func extractTextFromPDF(data []byte) (string, error) {
-	var text strings.Builder
-
-	// Simple PDF text extraction - look for text objects between BT/ET
-	content := string(data)
-	lines := strings.Split(content, "\n")
-
-	inTextObject := false
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "BT" {
-			inTextObject = true
-			continue
-		}
-		if line == "ET" {
-			inTextObject = false
-			continue
-		}
-		if inTextObject && strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")") {
-			// Extract text from PDF text showing operator
-			if len(line) > 2 {
-				text.WriteString(line[1 : len(line)-1])
-				text.WriteString(" ")
-			}
-		}
-	}
-
-	return strings.TrimSpace(text.String()), nil
-}
-
-// extractTextFromDOCX extracts text from DOCX files (ZIP archive with XML)
-func extractTextFromDOCX(data []byte) (string, error) {
-	// DOCX is a ZIP file containing document.xml
-	zipReader, err := zip.NewReader(strings.NewReader(string(data)), int64(len(data)))
-	if err != nil {
-		return "", fmt.Errorf("failed to read DOCX as ZIP: %v", err)
-	}
-
-	// Find document.xml
-	var docFile *zip.File
-	for _, file := range zipReader.File {
-		if file.Name == "word/document.xml" {
-			docFile = file
-			break
-		}
-	}
-
-	if docFile == nil {
-		return "", fmt.Errorf("document.xml not found in DOCX")
-	}
-
-	// Read document.xml
-	rc, err := docFile.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open document.xml: %v", err)
-	}
-	defer rc.Close()
-
-	xmlData, err := io.ReadAll(rc)
-	if err != nil {
-		return "", fmt.Errorf("failed to read document.xml: %v", err)
-	}
-
-	// Simple XML text extraction - look for text between <w:t> tags
-	content := string(xmlData)
-	var text strings.Builder
-
-	// Find all text elements
-	parts := strings.Split(content, "<w:t")
-	for _, part := range parts[1:] { // Skip first part before first <w:t>
-		if endIdx := strings.Index(part, "</w:t>"); endIdx != -1 {
-			textContent := part[:endIdx]
-			// Remove XML entities and clean up
-			textContent = strings.ReplaceAll(textContent, "&amp;", "&")
-			textContent = strings.ReplaceAll(textContent, "&lt;", "<")
-			textContent = strings.ReplaceAll(textContent, "&gt;", ">")
-			textContent = strings.ReplaceAll(textContent, "&quot;", "\"")
-			textContent = strings.ReplaceAll(textContent, "&apos;", "'")
-			text.WriteString(textContent)
-			text.WriteString(" ")
-		}
-	}
-
-	return strings.TrimSpace(text.String()), nil
-}
-
-// processDocuments extracts text from all provided document files
-func processDocuments(filePaths []string) (string, error) {
-	if len(filePaths) == 0 {
-		return "", nil
-	}
-
-	var allText strings.Builder
-	allText.WriteString("\n--- Document Context ---\n")
-
-	for _, filePath := range filePaths {
-		text, err := extractTextFromFile(filePath)
-		if err != nil {
-			return "", fmt.Errorf("error processing %s: %v", filePath, err)
-		}
-
-		allText.WriteString(fmt.Sprintf("\nFile: %s\n", filePath))
-		allText.WriteString(text)
-		allText.WriteString("\n\n")
-	}
-
-	allText.WriteString("--- End Document Context ---\n")
-	return allText.String(), nil
+	return encoded
 }
 
 // ai generates a response for a single prompt
@@ -277,10 +263,17 @@ func AI(prompt string, opts ...Option) string {
 	for _, opt := range opts {
 		opt(&options)
 	}
+	applyModelProviderPrefix(&options)
 
 	// Process documents if provided
 	if len(options.Docs) > 0 {
-		docText, err := processDocuments(options.Docs)
+		var docText string
+		var err error
+		if options.RAGStore != nil {
+			docText, err = ragContext(prompt, options)
+		} else {
+			docText, err = processDocuments(options.Docs, options)
+		}
 		if err != nil {
 			return fmt.Sprintf("Error processing documents: %v", err)
 		}
@@ -300,6 +293,44 @@ func AI(prompt string, opts ...Option) string {
 		prompt = "Be extremely concise and brief in your response. " + prompt
 	}
 
+	if len(options.Tools) > 0 {
+		return runToolCallLoop(options, prompt, aiOnce)
+	}
+	return aiOnce(prompt, options)
+}
+
+// ollamaBaseURL resolves the Ollama HTTP endpoint the legacy hardcoded
+// calls below should use: the resolved Provider's BaseURL when it's an
+// OllamaProvider with one set (e.g. WithProvider(OllamaProvider{BaseURL:
+// "..."})), or defaultClient.ollamaURL otherwise. Without this, an
+// OllamaProvider's BaseURL was silently discarded, since resolveProvider
+// only special-cased non-Ollama providers.
+func ollamaBaseURL(options RequestOptions) string {
+	if provider, ok := resolveProvider(options).(OllamaProvider); ok {
+		return provider.baseURL()
+	}
+	return defaultClient.ollamaURL
+}
+
+// aiOnce performs a single AI generation call: the Provider/Ollama dispatch
+// that AI wraps with the tool-calling loop when options.Tools is set.
+func aiOnce(prompt string, options RequestOptions) string {
+	// Route through a non-Ollama Provider when one is selected explicitly,
+	// via a "<provider>:<model>" Model string, or via BREEZE_PROVIDER;
+	// otherwise fall through to the original hardcoded Ollama HTTP calls
+	// below (still honoring a WithProvider(OllamaProvider{BaseURL: ...})
+	// override via ollamaBaseURL), preserving existing streaming/concise
+	// behavior.
+	if provider := resolveProvider(options); provider != nil {
+		if _, isOllama := provider.(OllamaProvider); !isOllama {
+			text, err := provider.Generate(reqCtx(options), options, prompt)
+			if err != nil {
+				return fmt.Sprintf("Error: %v", err)
+			}
+			return text
+		}
+	}
+
 	req := map[string]interface{}{
 		"model":  options.Model,
 		"prompt": prompt,
@@ -310,9 +341,15 @@ func AI(prompt string, opts ...Option) string {
 			"temperature": options.Temp,
 		}
 	}
+	if options.JSONSchema != "" {
+		req["format"] = ollamaFormat(options.JSONSchema)
+	}
+	if len(options.Images) > 0 {
+		req["images"] = encodeImages(options.Images)
+	}
 
 	jsonData, _ := json.Marshal(req)
-	resp, err := http.Post(defaultClient.ollamaURL+"/api/generate", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := postJSON(reqCtx(options), ollamaBaseURL(options)+"/api/generate", jsonData)
 	if err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
@@ -346,7 +383,16 @@ func AI(prompt string, opts ...Option) string {
 	return result["response"].(string)
 }
 
-// chat maintains conversation context
+// Chat is a convenience wrapper over the implicit defaultConversation: the
+// common case (no tools, no images) sends straight through
+// Conversation.Send, so Chat's history is persisted, forkable
+// (defaultConversation.Fork), and resumable across runs (LoadConversation
+// with defaultConversationID) instead of living only in a process-local
+// slice. Tool-calling and image turns still go through the legacy
+// defaultClient.messages path below, since ConversationMessage doesn't yet
+// carry tool_calls or attached images; those turns are mirrored into
+// defaultConversation afterwards so View/Fork see the full history either
+// way.
 func Chat(prompt string, opts ...Option) string {
 	options := RequestOptions{
 		Model:  defaultClient.model,
@@ -356,11 +402,18 @@ func Chat(prompt string, opts ...Option) string {
 	for _, opt := range opts {
 		opt(&options)
 	}
+	applyModelProviderPrefix(&options)
 
 	// Process documents if provided
 	userMessage := prompt
 	if len(options.Docs) > 0 {
-		docText, err := processDocuments(options.Docs)
+		var docText string
+		var err error
+		if options.RAGStore != nil {
+			docText, err = ragContext(prompt, options)
+		} else {
+			docText, err = processDocuments(options.Docs, options)
+		}
 		if err != nil {
 			return fmt.Sprintf("Error processing documents: %v", err)
 		}
@@ -372,11 +425,76 @@ func Chat(prompt string, opts ...Option) string {
 		userMessage = "Be extremely concise and brief in your response. " + userMessage
 	}
 
-	defaultClient.messages = append(defaultClient.messages, Message{Role: "user", Content: userMessage})
+	if len(options.Tools) == 0 && len(options.Images) == 0 {
+		response, err := defaultConversation.Send(userMessage, opts...)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		return response
+	}
+
+	if len(options.Tools) > 0 {
+		userMessage = userMessage + "\n\n" + renderToolSpecDescriptions(options.Tools)
+	}
+
+	defaultClient.messages = append(defaultClient.messages, Message{
+		Role:    "user",
+		Content: userMessage,
+		Images:  encodeImages(options.Images),
+	})
+
+	var response string
+	if len(options.Tools) > 0 {
+		response = runChatToolLoop(options)
+	} else {
+		response = chatOnce(options)
+	}
+
+	if err := defaultConversation.record("user", userMessage); err != nil {
+		fmt.Printf("⚠️  failed to record chat turn: %v\n", err)
+	} else if err := defaultConversation.record("assistant", response); err != nil {
+		fmt.Printf("⚠️  failed to record chat turn: %v\n", err)
+	}
+
+	return response
+}
+
+// chatOnce performs a single Ollama /api/chat call against
+// defaultClient.messages, appending the assistant's reply before returning
+// it. This is the non-tool-calling path Chat has always used.
+func chatOnce(options RequestOptions) string {
+	response, _ := chatCompletion(defaultClient.messages, options)
+	defaultClient.messages = append(defaultClient.messages, Message{Role: "assistant", Content: response})
+	return response
+}
+
+// chatCompletion performs a single chat-style generation over messages,
+// returning the assistant's reply. It's the shared core behind chatOnce
+// (which reads/appends defaultClient.messages) and Conversation.Send (which
+// reads/appends a ConversationStore instead), so both multi-turn chat
+// surfaces stay on one code path.
+//
+// When a non-Ollama Provider is selected (explicitly, via BREEZE_PROVIDER,
+// or via a "<provider>:<model>" Model string), it's routed through
+// Provider.Generate instead, flattening messages into a single prompt (see
+// renderPrompt) since Provider only exposes a single-prompt Generate, not a
+// chat-message API. The bool return reports whether the response was
+// already streamed to stdout (Concise+Stream), so callers don't print it
+// again.
+func chatCompletion(messages []Message, options RequestOptions) (string, bool) {
+	if provider := resolveProvider(options); provider != nil {
+		if _, isOllama := provider.(OllamaProvider); !isOllama {
+			text, err := provider.Generate(reqCtx(options), options, renderPrompt(messages))
+			if err != nil {
+				return fmt.Sprintf("Error: %v", err), false
+			}
+			return text, false
+		}
+	}
 
 	req := map[string]interface{}{
 		"model":    options.Model,
-		"messages": defaultClient.messages,
+		"messages": messages,
 		"stream":   options.Stream,
 	}
 	if options.Temp != 0.7 {
@@ -386,9 +504,9 @@ func Chat(prompt string, opts ...Option) string {
 	}
 
 	jsonData, _ := json.Marshal(req)
-	resp, err := http.Post(defaultClient.ollamaURL+"/api/chat", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := postJSON(reqCtx(options), ollamaBaseURL(options)+"/api/chat", jsonData)
 	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+		return fmt.Sprintf("Error: %v", err), false
 	}
 	defer resp.Body.Close()
 
@@ -412,8 +530,7 @@ func Chat(prompt string, opts ...Option) string {
 			}
 		}
 		fmt.Println() // New line after streaming
-		defaultClient.messages = append(defaultClient.messages, Message{Role: "assistant", Content: fullResponse.String()})
-		return fullResponse.String()
+		return fullResponse.String(), true
 	}
 
 	// Regular non-streaming chat response
@@ -421,10 +538,108 @@ func Chat(prompt string, opts ...Option) string {
 	var result map[string]interface{}
 	json.Unmarshal(body, &result)
 
-	response := result["message"].(map[string]interface{})["content"].(string)
-	defaultClient.messages = append(defaultClient.messages, Message{Role: "assistant", Content: response})
+	return result["message"].(map[string]interface{})["content"].(string), false
+}
 
-	return response
+// chatOnceForTools performs a single non-streaming /api/chat call with
+// Ollama's native "tools" field attached, appending the assistant's reply
+// to defaultClient.messages and returning it along with any tool calls the
+// model requested natively. Models/Ollama versions without native
+// tool-calling support return no tool_calls; runChatToolLoop falls back to
+// scanning the reply's content for the textual TOOL_CALL convention.
+func chatOnceForTools(options RequestOptions) (string, []toolCall) {
+	req := map[string]interface{}{
+		"model":    options.Model,
+		"messages": defaultClient.messages,
+		"stream":   false,
+		"tools":    ollamaToolDefs(options.Tools),
+	}
+	if options.Temp != 0.7 {
+		req["options"] = map[string]interface{}{
+			"temperature": options.Temp,
+		}
+	}
+
+	jsonData, _ := json.Marshal(req)
+	resp, err := postJSON(reqCtx(options), defaultClient.ollamaURL+"/api/chat", jsonData)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err), nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(body, &result)
+
+	message, _ := result["message"].(map[string]interface{})
+	content, _ := message["content"].(string)
+	defaultClient.messages = append(defaultClient.messages, Message{Role: "assistant", Content: content})
+
+	return content, parseOllamaToolCalls(message["tool_calls"])
+}
+
+// parseOllamaToolCalls decodes the "tool_calls" field of an Ollama /api/chat
+// message, matching the OpenAI function-calling JSON shape.
+func parseOllamaToolCalls(raw interface{}) []toolCall {
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	var calls []toolCall
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fn, ok := entry["function"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := fn["name"].(string)
+		if name == "" {
+			continue
+		}
+		args, _ := json.Marshal(fn["arguments"])
+		calls = append(calls, toolCall{Name: name, Args: args})
+	}
+	return calls
+}
+
+// runChatToolLoop drives Chat's tool-calling loop: each turn tries Ollama's
+// native tool_calls first, falling back to the textual TOOL_CALL convention,
+// invokes every requested tool, appends the results as role:"tool" messages,
+// and repeats until a turn requests no tool or MaxToolIterations is hit.
+func runChatToolLoop(options RequestOptions) string {
+	maxIterations := options.MaxToolIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultMaxToolIterations
+	}
+
+	for i := 0; i < maxIterations; i++ {
+		response, calls := chatOnceForTools(options)
+
+		if len(calls) == 0 {
+			match := toolCallPattern.FindStringSubmatch(response)
+			if match == nil {
+				return response
+			}
+			var call toolCall
+			if err := json.Unmarshal([]byte(match[1]), &call); err != nil {
+				return response
+			}
+			calls = []toolCall{call}
+		}
+
+		for _, call := range calls {
+			result := invokeToolSpec(reqCtx(options), options.Tools, call, options.ToolObserver)
+			defaultClient.messages = append(defaultClient.messages, Message{
+				Role:    "tool",
+				Content: fmt.Sprintf("%s: %s", call.Name, result),
+			})
+		}
+	}
+
+	return chatOnce(options)
 }
 
 // code is optimized for code generation
@@ -483,9 +698,14 @@ func pullModel(model string) {
 	}
 }
 
-// clear resets the conversation
+// clear resets the conversation, including defaultConversation's persisted
+// history, so a fresh Chat call starts a clean transcript rather than
+// resuming the one Clear was meant to discard.
 func Clear() {
 	defaultClient.messages = []Message{}
+	if err := defaultConversationStore.Truncate(defaultConversationID, ""); err != nil {
+		fmt.Printf("⚠️  failed to clear persisted chat history: %v\n", err)
+	}
 }
 
 // StreamFunc is the callback for streaming
@@ -514,7 +734,7 @@ func Stream(prompt string, fn StreamFunc, opts ...Option) {
 	}
 
 	jsonData, _ := json.Marshal(req)
-	resp, err := http.Post(defaultClient.ollamaURL+"/api/generate", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := postJSON(reqCtx(options), defaultClient.ollamaURL+"/api/generate", jsonData)
 	if err != nil {
 		fn(fmt.Sprintf("Error: %v", err))
 		return
@@ -536,17 +756,179 @@ func Stream(prompt string, fn StreamFunc, opts ...Option) {
 	}
 }
 
-// Batch processes multiple prompts concurrently
+// Token represents a single incrementally-streamed chunk of a response
+type Token struct {
+	Text        string
+	TokensSoFar int
+	ElapsedMs   int64
+}
+
+// AIStream streams a response token-by-token, returning a channel of Tokens
+// and a closer function that blocks until streaming is complete and returns
+// any error encountered.
+func AIStream(prompt string, opts ...Option) (<-chan Token, func() error) {
+	options := RequestOptions{
+		Model:  defaultClient.model,
+		Temp:   0.7,
+		Stream: true,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	applyModelProviderPrefix(&options)
+
+	if provider := resolveProvider(options); provider != nil {
+		if _, isOllama := provider.(OllamaProvider); !isOllama {
+			return provider.GenerateStream(reqCtx(options), options, prompt)
+		}
+	}
+
+	tokens := make(chan Token)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(tokens)
+
+		req := map[string]interface{}{
+			"model":  options.Model,
+			"prompt": prompt,
+			"stream": true,
+		}
+		if options.Temp != 0.7 {
+			req["options"] = map[string]interface{}{
+				"temperature": options.Temp,
+			}
+		}
+		if len(options.Images) > 0 {
+			req["images"] = encodeImages(options.Images)
+		}
+
+		jsonData, _ := json.Marshal(req)
+		start := time.Now()
+		resp, err := postJSON(reqCtx(options), ollamaBaseURL(options)+"/api/generate", jsonData)
+		if err != nil {
+			errCh <- fmt.Errorf("error: %v", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		tokensSoFar := 0
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var chunk map[string]interface{}
+			if err := decoder.Decode(&chunk); err != nil {
+				if err != io.EOF {
+					errCh <- err
+					return
+				}
+				break
+			}
+			if text, ok := chunk["response"].(string); ok && text != "" {
+				tokensSoFar++
+				tokens <- Token{
+					Text:        text,
+					TokensSoFar: tokensSoFar,
+					ElapsedMs:   time.Since(start).Milliseconds(),
+				}
+			}
+			if done, ok := chunk["done"].(bool); ok && done {
+				break
+			}
+		}
+		errCh <- nil
+	}()
+
+	return tokens, func() error {
+		return <-errCh
+	}
+}
+
+// BatchResult is the outcome of one prompt processed by BatchWithConcurrency
+// or BatchStream.
+type BatchResult struct {
+	Prompt   string
+	Response string
+	Err      error
+	Latency  time.Duration
+	// TokensIn/TokensOut are rough token-count estimates (len/4), since AI
+	// abstracts over Providers that don't uniformly report usage.
+	TokensIn  int
+	TokensOut int
+}
+
+// Batch processes multiple prompts concurrently and waits for all of them
+// to finish, returning each response in input order. For bounded
+// concurrency, cancellation, or progress as results arrive, use
+// BatchWithConcurrency or BatchStream.
 func Batch(prompts []string, opts ...Option) []string {
-	results := make([]string, len(prompts))
+	results := BatchWithConcurrency(context.Background(), prompts, len(prompts), opts...)
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.Response
+	}
+	return out
+}
+
+// BatchWithConcurrency processes prompts with at most n concurrent AI
+// calls, returning one BatchResult per prompt in input order. Canceling ctx
+// aborts in-flight Ollama requests (see WithCtx) and stops launching new
+// ones. n <= 0 means unbounded (one goroutine per prompt).
+func BatchWithConcurrency(ctx context.Context, prompts []string, n int, opts ...Option) []BatchResult {
+	results := make([]BatchResult, len(prompts))
+	BatchStream(ctx, prompts, n, func(idx int, res BatchResult) {
+		results[idx] = res
+	}, opts...)
+	return results
+}
+
+// BatchStream processes prompts with at most n concurrent AI calls,
+// invoking fn with each BatchResult as soon as it finishes (not
+// necessarily in input order), so callers can render progress. Canceling
+// ctx aborts in-flight Ollama requests (see WithCtx) and stops launching
+// new ones. n <= 0 means unbounded (one goroutine per prompt).
+func BatchStream(ctx context.Context, prompts []string, n int, fn func(idx int, res BatchResult), opts ...Option) {
+	if len(prompts) == 0 {
+		return
+	}
+	if n <= 0 || n > len(prompts) {
+		n = len(prompts)
+	}
+
+	callOpts := append(append([]Option{}, opts...), WithCtx(ctx))
+
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+
+loop:
 	for i, prompt := range prompts {
+		select {
+		case <-ctx.Done():
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
 		go func(idx int, p string) {
-			results[idx] = AI(p, opts...)
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			response := AI(p, callOpts...)
+			res := BatchResult{
+				Prompt:    p,
+				Response:  response,
+				Latency:   time.Since(start),
+				TokensIn:  len(p) / 4,
+				TokensOut: len(response) / 4,
+			}
+			if ctx.Err() != nil {
+				res.Err = ctx.Err()
+			}
+			fn(idx, res)
 		}(i, prompt)
 	}
-	// Wait for all to complete (simple implementation)
-	time.Sleep(5 * time.Second) // TODO: better synchronization
-	return results
+
+	wg.Wait()
 }
 
 // ===== COLLABORATIVE AI FRAMEWORK =====
@@ -557,6 +939,28 @@ type Agent struct {
 	Role        string
 	Expertise   string
 	Personality string
+	// Backend, if set, overrides the package-level default backend for this
+	// agent's turns, letting a single Collaboration mix providers (e.g. a
+	// cheap local model for parallel phases and a stronger one for synthesis).
+	Backend Backend
+	// Provider and Model pin this agent to a specific LLM vendor/model (e.g.
+	// "Critic" on GPT-4o while "Composer" stays on the local Ollama default),
+	// overriding the package/env default for this agent's turns only.
+	Provider Provider
+	Model    string
+	// Tools, if non-empty, makes this agent's turns run the standard
+	// tool-calling loop (see runAgentTurnWithTools) instead of a single
+	// one-shot generation.
+	Tools []Tool
+	// When, if set, is an expression (see EvalContext) gating this agent's
+	// participation in a phase, e.g. "phase.name == 'Technical Design'". An
+	// agent whose When evaluates false sits out that phase entirely.
+	When string
+	// Seniority weights this agent's influence in consolidation steps that
+	// aggregate multiple agents' output (e.g. peer review scoring). Zero
+	// means "unset"; callers that weight by Seniority should treat 0 as 1
+	// (an unweighted vote) rather than excluding the agent entirely.
+	Seniority float64
 }
 
 // Phase represents a collaborative phase with specific instructions
@@ -564,10 +968,70 @@ type Phase struct {
 	Name           string
 	Description    string
 	PromptTemplate string
-	IsParallel     bool
+	// IsParallel and MaxConcurrency select between Collaboration's two
+	// built-in execution strategies.
+	//
+	// Deprecated: set Method to Sequential(), Parallel(n), or another
+	// CollaborationMethod instead. IsParallel/MaxConcurrency are still read
+	// when Method is unset, so existing phases keep working unchanged.
+	IsParallel bool
+	// Deprecated: see IsParallel.
 	MaxConcurrency int
+	// Method, if set, takes over running this phase's agents entirely,
+	// superseding IsParallel/MaxConcurrency. See CollaborationMethod,
+	// Sequential, Parallel, PeerReview, Consensus, DebateStyle, and
+	// CoderStrategy for the built-ins. Only meaningful for Collaboration,
+	// not TeamCollaboration.
+	Method CollaborationMethod
+	// Verifier, if set, mechanically checks each agent's output for this
+	// phase before it is accepted. On Refuted/Inconclusive, the agent is
+	// re-prompted with the verifier's feedback, up to MaxRepairRounds times.
+	Verifier Verifier
+	// Attachments are image file paths shared with every agent in this phase
+	// (e.g. a UI mockup shown to every agent in a "Design Review" phase). See
+	// WithImages for per-request images.
+	Attachments []string
+	// Condition, if set, is an expression (see EvalContext) evaluated before
+	// this phase runs; a false result skips the phase entirely. An unset
+	// Condition always runs, preserving the static pipeline behavior.
+	Condition string
+	// PostStep runs, in order, after an agent's output is verified/repaired
+	// and before it lands in the phase results. Each middleware can rewrite
+	// the output (e.g. append diagnostics) or extract side artifacts; a
+	// middleware error is logged and the output from the previous step is
+	// kept rather than losing the agent's turn.
+	PostStep []PhaseMiddleware
+	// DependsOn names the phases (by Phase.Name) that must complete before
+	// TeamCollaboration.Run starts this one. An empty DependsOn defaults to
+	// depending on the previous phase in Phases order, preserving the
+	// original strictly-linear behavior; phases with disjoint DependsOn can
+	// run concurrently and later re-converge. Only meaningful for
+	// TeamCollaboration, not Collaboration.
+	DependsOn []string
+	// Produces documents which SharedKnowledge keys this phase is expected
+	// to write, purely for Graphviz labeling - it isn't enforced.
+	Produces []string
+	// AgentDependsOn narrows a specific agent's wait (by Agent.Name, keying
+	// into this map) to a named list of other agents instead of this
+	// phase's whole DependsOn: the agent blocks until those agents'
+	// SharedKnowledge entries are recorded, then proceeds even if the rest
+	// of its own phase's dependencies haven't finished. Only meaningful for
+	// TeamCollaboration.
+	AgentDependsOn map[string][]string
 }
 
+// PhaseContext carries the metadata available to a PhaseMiddleware: which
+// phase and agent produced the output it's about to process.
+type PhaseContext struct {
+	Phase Phase
+	Agent Agent
+}
+
+// PhaseMiddleware post-processes one agent's raw phase output, e.g. to
+// extract structured artifacts or run external validation before the
+// result is recorded. Returning an error keeps the output unchanged.
+type PhaseMiddleware func(ctx PhaseContext, output string) (string, error)
+
 // Collaboration manages multi-agent collaborative workflows
 type Collaboration struct {
 	Agents          []Agent
@@ -575,6 +1039,54 @@ type Collaboration struct {
 	SharedKnowledge map[string]string
 	OnPhaseComplete func(phaseName string, results map[string]string)
 	OnAgentResponse func(agentName, response string)
+	// OnAgentToken, if set, is called for every streamed token produced while
+	// an agent is "thinking aloud" during a phase. Requires StreamAgentTokens.
+	OnAgentToken func(agentName, token string)
+	// StreamAgentTokens switches agent turns to use AIStream so OnAgentToken
+	// fires incrementally instead of only OnAgentResponse firing once.
+	StreamAgentTokens bool
+	// MaxRepairRounds bounds how many times an agent is re-prompted with a
+	// verifier's counterexample before its Phase.Verifier result is accepted
+	// as-is. Defaults to 0 (no repair) when unset.
+	MaxRepairRounds int
+	// MaxToolIterations bounds the tool-call loop for agents with Tools set.
+	// Defaults to defaultMaxToolIterations when unset.
+	MaxToolIterations int
+	// Ctx governs cancellation of in-flight tool calls made during
+	// runAgentTurnWithTools. Nil means context.Background(). Mirrors
+	// RequestOptions.Ctx.
+	Ctx context.Context
+	// ConversationID, if set, makes Run record every agent's turn, across
+	// every phase, into the package-level default ConversationStore under
+	// this ID (one branch per agent, parented phase-to-phase), so a crashed
+	// run can be resumed or a later phase re-run as a Conversation.Fork.
+	ConversationID string
+	// Router, if set, is an expression (see EvalContext) evaluated after
+	// each phase completes; its string result is the name of the next phase
+	// to run, enabling loops (e.g. a phase whose Condition is
+	// "contains(results['Critique']['Critic'], 'needs work')" combined with
+	// a Router that sends the workflow back to "Lyrics Creation"). A result
+	// that doesn't name a phase, or an unset Router, just continues to the
+	// next phase in Phases order.
+	Router string
+	// Policy, if set, is consulted by every built-in CollaborationMethod
+	// (Sequential, Parallel, PeerReview, Consensus, DebateStyle,
+	// CoderStrategy) before each agent AI call and after each response - see
+	// PolicyEngine, PolicyRequest, DefaultPolicyBundle. Nil means every call
+	// is allowed unchanged, preserving existing behavior.
+	Policy *PolicyEngine
+	// OnPolicyDecision, if set, is called with every PolicyDecision Policy
+	// produces, for audit logging.
+	OnPolicyDecision func(PolicyDecision)
+	// Recorder, if set, captures a CallRecord for every AI call made through
+	// a built-in CollaborationMethod (see policyAI), so a completed run can
+	// later be written out with ExportSupportBundle.
+	Recorder *CallRecorder
+	// RedactPatterns are applied to every prompt, response, and
+	// SharedKnowledge value ExportSupportBundle writes into a bundle - each
+	// match is replaced with "[REDACTED]" so secrets or PII captured in a
+	// run's trace don't end up on disk unredacted.
+	RedactPatterns []*regexp.Regexp
 }
 
 // NewCollaboration creates a new collaborative workflow
@@ -586,14 +1098,54 @@ func NewCollaboration(agents []Agent, phases []Phase) *Collaboration {
 	}
 }
 
-// Run executes the entire collaborative workflow
+// maxRouterIterations bounds a Router loop so a Router that never routes
+// back to an earlier phase's Condition turning false can't run forever.
+const maxRouterIterations = 50
+
+// Run executes the entire collaborative workflow. With no Phase.Condition,
+// Agent.When, or Collaboration.Router set anywhere, this is the original
+// static, linear pipeline; setting any of them turns it into a small
+// workflow engine that can skip phases/agents or loop back to an earlier
+// phase. See EvalContext, ValidateExpressions.
 func (c *Collaboration) Run(initialPrompt string) (map[string]map[string]string, error) {
+	return c.runCollaborationLoop(initialPrompt, func(phase Phase) map[string]string {
+		return c.runPhase(phase, initialPrompt)
+	})
+}
+
+// runCollaborationLoop drives Phases' Condition/Agent.When/Router/
+// ConversationID machinery, delegating each phase's actual execution to
+// runOne. Run and RunWithOutcomes share this so the two only differ in how
+// a single phase's agents are turned into a result (bare strings vs.
+// AgentOutcomes), not in the control flow around them.
+func (c *Collaboration) runCollaborationLoop(initialPrompt string, runOne func(phase Phase) map[string]string) (map[string]map[string]string, error) {
 	results := make(map[string]map[string]string)
+	lastMsgID := make(map[string]string) // agent name -> last recorded message ID
+
+	phaseIndex := make(map[string]int, len(c.Phases))
+	for i, phase := range c.Phases {
+		phaseIndex[phase.Name] = i
+	}
+
+	i := 0
+	iteration := 0
+	for i < len(c.Phases) && iteration < maxRouterIterations {
+		phase := c.Phases[i]
+		iteration++
+
+		evalCtx := EvalContext{Phase: phase, Agents: c.Agents, Results: results, SharedKnowledge: c.SharedKnowledge, Iteration: iteration}
+		run, err := evalCondition(phase.Condition, evalCtx)
+		if err != nil {
+			return results, &ExpressionError{Field: fmt.Sprintf("Phase(%s).Condition", phase.Name), Expr: phase.Condition, Err: err}
+		}
+		if !run {
+			i++
+			continue
+		}
 
-	for _, phase := range c.Phases {
 		fmt.Printf("\n🔄 PHASE: %s\n%s\n", phase.Name, phase.Description)
 
-		phaseResults := c.runPhase(phase, initialPrompt)
+		phaseResults := runOne(phase)
 		results[phase.Name] = phaseResults
 
 		// Update shared knowledge
@@ -601,26 +1153,123 @@ func (c *Collaboration) Run(initialPrompt string) (map[string]map[string]string,
 			c.SharedKnowledge[agentName] = response
 		}
 
+		if c.ConversationID != "" {
+			c.recordPhase(phase, phaseResults, lastMsgID)
+		}
+
 		if c.OnPhaseComplete != nil {
 			c.OnPhaseComplete(phase.Name, phaseResults)
 		}
+
+		next := i + 1
+		if c.Router != "" {
+			evalCtx.Results = results
+			routed, err := evalRouter(c.Router, evalCtx)
+			if err != nil {
+				return results, &ExpressionError{Field: "Collaboration.Router", Expr: c.Router, Err: err}
+			}
+			if idx, ok := phaseIndex[routed]; ok {
+				next = idx
+			}
+		}
+		i = next
 	}
 
 	return results, nil
 }
 
+// evalCondition compiles and evaluates expr as a Phase.Condition/Agent.When
+// would. An empty expr always runs (the static-pipeline default).
+func evalCondition(expr string, ctx EvalContext) (bool, error) {
+	compiled, err := compileExpr(expr)
+	if err != nil {
+		return false, err
+	}
+	return compiled.evalBool(ctx)
+}
+
+// evalRouter compiles and evaluates expr as Collaboration.Router does,
+// naming the next phase to run.
+func evalRouter(expr string, ctx EvalContext) (string, error) {
+	compiled, err := compileExpr(expr)
+	if err != nil {
+		return "", err
+	}
+	return compiled.evalString(ctx)
+}
+
+// ValidateExpressions compiles every Phase.Condition, every Agent.When, and
+// Collaboration.Router up front, returning the first *ExpressionError found.
+// Call it after constructing a Collaboration that uses any of these fields
+// to catch a typo before Run rather than mid-workflow.
+func (c *Collaboration) ValidateExpressions() error {
+	for _, phase := range c.Phases {
+		if _, err := compileExpr(phase.Condition); err != nil {
+			return &ExpressionError{Field: fmt.Sprintf("Phase(%s).Condition", phase.Name), Expr: phase.Condition, Err: err}
+		}
+	}
+	for _, agent := range c.Agents {
+		if _, err := compileExpr(agent.When); err != nil {
+			return &ExpressionError{Field: fmt.Sprintf("Agent(%s).When", agent.Name), Expr: agent.When, Err: err}
+		}
+	}
+	if _, err := compileExpr(c.Router); err != nil {
+		return &ExpressionError{Field: "Collaboration.Router", Expr: c.Router, Err: err}
+	}
+	return nil
+}
+
+// recordPhase appends one ConversationMessage per agent response in phase to
+// the default ConversationStore under c.ConversationID, parenting each
+// message to that agent's last recorded message so the full run forms a
+// per-agent chain across phases. lastMsgID is updated in place.
+func (c *Collaboration) recordPhase(phase Phase, phaseResults map[string]string, lastMsgID map[string]string) {
+	for _, agent := range c.Agents {
+		response, ok := phaseResults[agent.Name]
+		if !ok {
+			continue
+		}
+
+		msg := ConversationMessage{
+			ID:        fmt.Sprintf("%s-%s", phase.Name, agent.Name),
+			ParentID:  lastMsgID[agent.Name],
+			Role:      "assistant",
+			Agent:     agent.Name,
+			Phase:     phase.Name,
+			Model:     agent.Model,
+			Content:   response,
+			Timestamp: time.Now(),
+		}
+		if err := defaultConversationStore.Append(c.ConversationID, msg); err != nil {
+			fmt.Printf("⚠️  failed to record conversation turn for %s/%s: %v\n", phase.Name, agent.Name, err)
+			continue
+		}
+		lastMsgID[agent.Name] = msg.ID
+	}
+}
+
 // runPhase executes a single collaborative phase
 func (c *Collaboration) runPhase(phase Phase, initialPrompt string) map[string]string {
 	results := make(map[string]string)
 
+	if phase.Method != nil {
+		return phase.Method(c.participatingAgents(phase), c, phase, initialPrompt)
+	}
+
 	if phase.IsParallel {
 		return c.runParallelPhase(phase, initialPrompt)
 	}
 
 	// Sequential execution
 	for _, agent := range c.Agents {
-		prompt := c.buildAgentPrompt(agent, phase, initialPrompt)
-		response := AI(prompt, WithConcise())
+		if !c.agentParticipates(agent, phase) {
+			continue
+		}
+
+		prompt := c.BuildAgentPrompt(agent, phase, initialPrompt)
+		response := c.runAgentTurnWithTools(agent, prompt, phase.Attachments...)
+		response = c.verifyAndRepair(agent, phase, initialPrompt, response)
+		response = c.runPostStep(agent, phase, response)
 
 		results[agent.Name] = response
 
@@ -632,6 +1281,150 @@ func (c *Collaboration) runPhase(phase Phase, initialPrompt string) map[string]s
 	return results
 }
 
+// runPostStep applies phase.PostStep middleware in order. A middleware
+// error is logged and that step is skipped, keeping the output from the
+// previous step rather than losing the agent's turn.
+func (c *Collaboration) runPostStep(agent Agent, phase Phase, response string) string {
+	ctx := PhaseContext{Phase: phase, Agent: agent}
+	for _, mw := range phase.PostStep {
+		out, err := mw(ctx, response)
+		if err != nil {
+			fmt.Printf("⚠️  PostStep middleware failed for %s/%s: %v\n", phase.Name, agent.Name, err)
+			continue
+		}
+		response = out
+	}
+	return response
+}
+
+// agentParticipates evaluates agent.When (if set) to decide whether agent
+// sits out phase entirely. An unset When always participates.
+func (c *Collaboration) agentParticipates(agent Agent, phase Phase) bool {
+	if agent.When == "" {
+		return true
+	}
+	ok, err := evalCondition(agent.When, EvalContext{Phase: phase, Agents: c.Agents, SharedKnowledge: c.SharedKnowledge})
+	if err != nil {
+		fmt.Printf("⚠️  Agent(%s).When failed to evaluate, letting it participate: %v\n", agent.Name, err)
+		return true
+	}
+	return ok
+}
+
+// participatingAgents filters c.Agents down to those whose Agent.When
+// admits them into phase, in Agents order, for handing off to a
+// Phase.Method.
+func (c *Collaboration) participatingAgents(phase Phase) []Agent {
+	agents := make([]Agent, 0, len(c.Agents))
+	for _, agent := range c.Agents {
+		if c.agentParticipates(agent, phase) {
+			agents = append(agents, agent)
+		}
+	}
+	return agents
+}
+
+// verifyAndRepair runs phase.Verifier (if set) against an agent's response
+// and, on Refuted/Inconclusive, re-prompts the agent with the verifier's
+// feedback, up to c.MaxRepairRounds times.
+func (c *Collaboration) verifyAndRepair(agent Agent, phase Phase, initialPrompt, response string) string {
+	repaired, _, _ := c.verifyAndRepairTracked(agent, phase, initialPrompt, response)
+	return repaired
+}
+
+// verifyAndRepairTracked behaves like verifyAndRepair but also reports how
+// many repair rounds ran and the verifier's final status, so callers that
+// want a structured AgentOutcome (see RunWithOutcomes) don't have to re-run
+// verification themselves.
+func (c *Collaboration) verifyAndRepairTracked(agent Agent, phase Phase, initialPrompt, response string) (string, int, *VerificationResult) {
+	if phase.Verifier == nil {
+		return response, 0, nil
+	}
+
+	var last VerificationResult
+	retries := 0
+	for round := 0; round < c.MaxRepairRounds; round++ {
+		last = phase.Verifier.Verify(initialPrompt, response)
+		if last.Status == Verified {
+			return response, retries, &last
+		}
+
+		repairPrompt := fmt.Sprintf(
+			"Your previous answer was %s by an automated checker: %s\nCounterexample/detail: %s\n\nYOUR PREVIOUS ANSWER:\n%s\n\nPlease correct it and provide a revised answer.",
+			last.Status, last.Detail, last.Counterexample, response)
+		response = c.runAgentTurn(agent, repairPrompt)
+		retries++
+	}
+	last = phase.Verifier.Verify(initialPrompt, response)
+
+	return response, retries, &last
+}
+
+// runAgentTurn generates an agent's response, streaming tokens through
+// OnAgentToken when StreamAgentTokens is enabled, and honoring a per-agent
+// Backend override when present. imagePaths, if given, are typically a
+// Phase's Attachments, shared with the agent via WithImages; Backend-routed
+// turns don't support images yet.
+func (c *Collaboration) runAgentTurn(agent Agent, prompt string, imagePaths ...string) string {
+	backend := agent.Backend
+	if backend == nil {
+		backend = defaultBackend
+	}
+
+	if backend != nil {
+		messages := []Message{{Role: "user", Content: prompt}}
+		options := RequestOptions{Temp: 0.7}
+		if !c.StreamAgentTokens {
+			text, err := backend.Generate(context.Background(), messages, options)
+			if err != nil {
+				return fmt.Sprintf("Error: %v", err)
+			}
+			return text
+		}
+
+		var response strings.Builder
+		tokens, closer := backend.GenerateStream(context.Background(), messages, options)
+		for tok := range tokens {
+			response.WriteString(tok.Text)
+			if c.OnAgentToken != nil {
+				c.OnAgentToken(agent.Name, tok.Text)
+			}
+		}
+		if err := closer(); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		return response.String()
+	}
+
+	aiOpts := []Option{WithConcise()}
+	if agent.Provider != nil {
+		aiOpts = append(aiOpts, WithProvider(agent.Provider))
+	}
+	if agent.Model != "" {
+		aiOpts = append(aiOpts, WithModel(agent.Model))
+	}
+	if len(imagePaths) > 0 {
+		aiOpts = append(aiOpts, WithImages(imagePaths...))
+	}
+
+	if !c.StreamAgentTokens {
+		return AI(prompt, aiOpts...)
+	}
+
+	var response strings.Builder
+	tokens, closer := AIStream(prompt, aiOpts...)
+	for tok := range tokens {
+		response.WriteString(tok.Text)
+		if c.OnAgentToken != nil {
+			c.OnAgentToken(agent.Name, tok.Text)
+		}
+	}
+	if err := closer(); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return response.String()
+}
+
 // runParallelPhase executes agents in parallel
 func (c *Collaboration) runParallelPhase(phase Phase, initialPrompt string) map[string]string {
 	results := make(map[string]string)
@@ -646,6 +1439,10 @@ func (c *Collaboration) runParallelPhase(phase Phase, initialPrompt string) map[
 	semaphore := make(chan struct{}, maxConcurrency)
 
 	for _, agent := range c.Agents {
+		if !c.agentParticipates(agent, phase) {
+			continue
+		}
+
 		wg.Add(1)
 		go func(agent Agent) {
 			defer wg.Done()
@@ -653,8 +1450,10 @@ func (c *Collaboration) runParallelPhase(phase Phase, initialPrompt string) map[
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
-			prompt := c.buildAgentPrompt(agent, phase, initialPrompt)
-			response := AI(prompt, WithConcise())
+			prompt := c.BuildAgentPrompt(agent, phase, initialPrompt)
+			response := c.runAgentTurnWithTools(agent, prompt, phase.Attachments...)
+			response = c.verifyAndRepair(agent, phase, initialPrompt, response)
+			response = c.runPostStep(agent, phase, response)
 
 			mu.Lock()
 			results[agent.Name] = response
@@ -670,8 +1469,11 @@ func (c *Collaboration) runParallelPhase(phase Phase, initialPrompt string) map[
 	return results
 }
 
-// buildAgentPrompt constructs the prompt for an agent in a specific phase
-func (c *Collaboration) buildAgentPrompt(agent Agent, phase Phase, initialPrompt string) string {
+// BuildAgentPrompt constructs the prompt for an agent in a specific phase,
+// folding in c.SharedKnowledge from every other agent. Exported so a
+// CollaborationMethod (see Phase.Method) can build the same prompt a
+// built-in method like Sequential or Parallel would.
+func (c *Collaboration) BuildAgentPrompt(agent Agent, phase Phase, initialPrompt string) string {
 	prompt := fmt.Sprintf("You are %s, %s with expertise in %s. %s\n\n",
 		agent.Name, agent.Role, agent.Expertise, agent.Personality)
 
@@ -760,61 +1562,272 @@ type TeamCollaboration struct {
 	OnPhaseComplete func(phaseName string, results map[string]string)
 	OnAgentResponse func(agentName, response string)
 	OnTeamComplete  func(teamName string, results map[string]string)
+	// ArtifactSinks receive a structured PhaseArtifact after every phase
+	// completes, alongside OnPhaseComplete's flat callback - see
+	// MarkdownSummarySink, JSONLSink, HTTPSink.
+	ArtifactSinks []ArtifactSink
+	// Checkpointer persists a CollabState after every phase and is
+	// consulted at the start of Run so a mid-run failure doesn't force
+	// re-running already-completed phases. Defaults to NoopCheckpointer
+	// (no persistence) unless set directly or via WithCheckpointer.
+	Checkpointer Checkpointer
+
+	// knowledgeMu/knowledgeCond guard SharedKnowledge now that Run executes
+	// independent phases (and, within a phase, independent agents)
+	// concurrently; knowledgeCond wakes agents blocked in waitForAgentDeps
+	// whenever a new entry is recorded.
+	knowledgeMu   sync.Mutex
+	knowledgeCond *sync.Cond
+	// graphErr is any cycle/unknown-dependency error found across Phases at
+	// construction time; Run returns it immediately instead of executing a
+	// broken DAG.
+	graphErr error
 }
 
-// NewTeamCollaboration creates a new team-based collaborative workflow
-func NewTeamCollaboration(teams []Team, phases []Phase) *TeamCollaboration {
-	return &TeamCollaboration{
+// TeamCollaborationOption configures a *TeamCollaboration at construction
+// time. See WithCheckpointer.
+type TeamCollaborationOption func(*TeamCollaboration)
+
+// WithCheckpointer attaches cp to the constructed TeamCollaboration, the
+// same as setting its Checkpointer field directly - provided for callers
+// that prefer configuring everything through NewTeamCollaboration's
+// argument list.
+func WithCheckpointer(cp Checkpointer) TeamCollaborationOption {
+	return func(tc *TeamCollaboration) {
+		tc.Checkpointer = cp
+	}
+}
+
+// NewTeamCollaboration creates a new team-based collaborative workflow,
+// validating phases' DependsOn for unknown references and cycles, and every
+// phase's AgentDependsOn for unknown agents and cycles, up front (see
+// Phase.DependsOn, Phase.AgentDependsOn, Run, Graphviz).
+func NewTeamCollaboration(teams []Team, phases []Phase, opts ...TeamCollaborationOption) *TeamCollaboration {
+	tc := &TeamCollaboration{
 		Teams:           teams,
 		Phases:          phases,
 		SharedKnowledge: make(map[string]string),
+		Checkpointer:    NoopCheckpointer{},
+	}
+	tc.knowledgeCond = sync.NewCond(&tc.knowledgeMu)
+	tc.graphErr = validatePhaseGraph(phases)
+	if tc.graphErr == nil {
+		tc.graphErr = validateAgentDependencies(teams, phases)
 	}
+	for _, opt := range opts {
+		opt(tc)
+	}
+	return tc
 }
 
-// Run executes the team collaborative workflow with automatic team alternation
+// ValidateExpressions compiles every Phase.Condition and every Agent.When
+// across all teams up front, returning the first *ExpressionError found. See
+// Collaboration.ValidateExpressions.
+func (tc *TeamCollaboration) ValidateExpressions() error {
+	for _, phase := range tc.Phases {
+		if _, err := compileExpr(phase.Condition); err != nil {
+			return &ExpressionError{Field: fmt.Sprintf("Phase(%s).Condition", phase.Name), Expr: phase.Condition, Err: err}
+		}
+	}
+	for _, team := range tc.Teams {
+		for _, agent := range team.Agents {
+			if _, err := compileExpr(agent.When); err != nil {
+				return &ExpressionError{Field: fmt.Sprintf("Agent(%s).When", agent.Name), Expr: agent.When, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// Run executes the team collaborative workflow over Phases' dependency DAG
+// (see Phase.DependsOn): every phase in a layer runs concurrently once the
+// phases it depends on have completed, instead of strictly in Phases order.
+// A Phase.Condition (see EvalContext) that evaluates false skips that
+// phase; an unset Condition always runs.
 func (tc *TeamCollaboration) Run(initialPrompt string) (map[string]map[string]string, error) {
+	if tc.graphErr != nil {
+		return nil, tc.graphErr
+	}
+	if tc.Checkpointer == nil {
+		tc.Checkpointer = NoopCheckpointer{}
+	}
+
+	layers, err := phaseLayers(tc.Phases)
+	if err != nil {
+		return nil, err
+	}
+
 	results := make(map[string]map[string]string)
+	completed := make(map[string]bool)
+	specHash := tc.specHash()
 
-	for _, phase := range tc.Phases {
-		fmt.Printf("\n🔄 PHASE: %s\n%s\n", phase.Name, phase.Description)
+	if state, err := tc.Checkpointer.Load(); err == nil {
+		if state.SpecHash != specHash {
+			return nil, fmt.Errorf("team collaboration: checkpoint spec hash %q does not match current spec %q; the pipeline changed since the checkpoint was written", state.SpecHash, specHash)
+		}
+		for name, phaseResults := range state.Results {
+			results[name] = phaseResults
+			completed[name] = true
+		}
+		for name, value := range state.SharedKnowledge {
+			tc.SharedKnowledge[name] = value
+		}
+		if len(completed) > 0 {
+			fmt.Printf("📌 Resuming from checkpoint: %d phase(s) already completed\n", len(completed))
+		}
+	}
 
-		// Execute phase for all teams
-		phaseResults := tc.runTeamPhase(phase, initialPrompt)
-		results[phase.Name] = phaseResults
+	var resultsMu sync.Mutex
 
-		// Update shared knowledge
-		for agentName, response := range phaseResults {
-			tc.SharedKnowledge[agentName] = response
+	for _, layer := range layers {
+		var runnable []Phase
+		for _, phase := range layer {
+			if completed[phase.Name] {
+				continue
+			}
+			run, evalErr := evalCondition(phase.Condition, EvalContext{Phase: phase, Results: results, SharedKnowledge: tc.knowledgeSnapshot(), Iteration: len(results) + 1})
+			if evalErr != nil {
+				return results, &ExpressionError{Field: fmt.Sprintf("Phase(%s).Condition", phase.Name), Expr: phase.Condition, Err: evalErr}
+			}
+			if run {
+				runnable = append(runnable, phase)
+			}
 		}
 
-		if tc.OnPhaseComplete != nil {
-			tc.OnPhaseComplete(phase.Name, phaseResults)
+		var wg sync.WaitGroup
+		for _, phase := range runnable {
+			wg.Add(1)
+			go func(phase Phase) {
+				defer wg.Done()
+
+				fmt.Printf("\n🔄 PHASE: %s\n%s\n", phase.Name, phase.Description)
+
+				phaseStart := time.Now()
+				phaseResults, teamResults := tc.runTeamPhase(phase, initialPrompt)
+				phaseDuration := time.Since(phaseStart)
+
+				resultsMu.Lock()
+				results[phase.Name] = phaseResults
+				resultsMu.Unlock()
+
+				if tc.OnPhaseComplete != nil {
+					tc.OnPhaseComplete(phase.Name, phaseResults)
+				}
+
+				tc.emitPhaseArtifact(phase.Name, teamResults, phaseDuration)
+			}(phase)
+		}
+		wg.Wait()
+
+		if len(runnable) == 0 {
+			continue
+		}
+
+		// Checkpointed once per layer, after every phase in it has finished,
+		// instead of from inside each phase's goroutine: two phases in the
+		// same layer finish in whichever order their goroutines happen to
+		// land in, so a per-phase Save would race with no ordering between
+		// "snapshot taken" and "file write lands" - a phase that snapshotted
+		// results early could overwrite a sibling's already-saved, more
+		// complete checkpoint. Saving the merged results after wg.Wait()
+		// makes the on-disk state monotonic: it always reflects every phase
+		// this layer completed.
+		resultsMu.Lock()
+		resultsCopy := make(map[string]map[string]string, len(results))
+		for name, r := range results {
+			resultsCopy[name] = r
+		}
+		resultsMu.Unlock()
+
+		if err := tc.Checkpointer.Save(CollabState{SpecHash: specHash, Results: resultsCopy, SharedKnowledge: tc.knowledgeSnapshot()}); err != nil {
+			fmt.Printf("⚠️  failed to save checkpoint after layer: %v\n", err)
 		}
 	}
 
 	return results, nil
 }
 
-// runTeamPhase executes a phase across all teams
-func (tc *TeamCollaboration) runTeamPhase(phase Phase, initialPrompt string) map[string]string {
+// emitPhaseArtifact builds a PhaseArtifact for a just-completed phase and
+// hands it to every configured ArtifactSink. A sink error is logged, not
+// fatal - a broken summary file shouldn't abort the run.
+func (tc *TeamCollaboration) emitPhaseArtifact(phaseName string, teamResults map[string]map[string]string, duration time.Duration) {
+	if len(tc.ArtifactSinks) == 0 {
+		return
+	}
+
+	tokensUsed := 0
+	for _, agentResults := range teamResults {
+		for _, response := range agentResults {
+			tokensUsed += len(response) / 4
+		}
+	}
+
+	artifact := PhaseArtifact{
+		PhaseName:       phaseName,
+		TeamResults:     teamResults,
+		Duration:        duration,
+		TokensUsed:      tokensUsed,
+		SharedKnowledge: tc.knowledgeSnapshot(),
+	}
+
+	for _, sink := range tc.ArtifactSinks {
+		if err := sink.EmitPhase(artifact); err != nil {
+			fmt.Printf("⚠️  artifact sink failed for phase %q: %v\n", phaseName, err)
+		}
+	}
+}
+
+// runTeamPhase executes a phase across all teams, returning both the flat
+// agentName->response map used elsewhere and a per-team breakdown for
+// ArtifactSinks. Teams run concurrently, not one after another: a
+// Phase.AgentDependsOn entry can name an agent on a different team in the
+// same phase (e.g. a backend dev waiting on a backend tester), and that
+// dependency can only resolve if the tester's team is actually running
+// rather than waiting its turn behind the dev's team.
+func (tc *TeamCollaboration) runTeamPhase(phase Phase, initialPrompt string) (map[string]string, map[string]map[string]string) {
 	results := make(map[string]string)
+	byTeam := make(map[string]map[string]string, len(tc.Teams))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 
 	for _, team := range tc.Teams {
-		fmt.Printf("👥 %s team working...\n", team.Name)
+		wg.Add(1)
+		go func(team Team) {
+			defer wg.Done()
 
-		teamResults := tc.runTeamAgents(team, phase, initialPrompt)
+			fmt.Printf("👥 %s team working...\n", team.Name)
 
-		// Merge team results
-		for agentName, response := range teamResults {
-			results[agentName] = response
-		}
+			teamResults := tc.runTeamAgents(team, phase, initialPrompt)
 
-		if tc.OnTeamComplete != nil {
-			tc.OnTeamComplete(team.Name, teamResults)
-		}
+			mu.Lock()
+			byTeam[team.Name] = teamResults
+			for agentName, response := range teamResults {
+				results[agentName] = response
+			}
+			mu.Unlock()
+
+			if tc.OnTeamComplete != nil {
+				tc.OnTeamComplete(team.Name, teamResults)
+			}
+		}(team)
 	}
+	wg.Wait()
 
-	return results
+	return results, byTeam
+}
+
+// agentParticipates evaluates agent.When (if set) to decide whether agent
+// sits out phase entirely. An unset When always participates.
+func (tc *TeamCollaboration) agentParticipates(agent Agent, phase Phase) bool {
+	if agent.When == "" {
+		return true
+	}
+	ok, err := evalCondition(agent.When, EvalContext{Phase: phase, SharedKnowledge: tc.knowledgeSnapshot()})
+	if err != nil {
+		fmt.Printf("⚠️  Agent(%s).When failed to evaluate, letting it participate: %v\n", agent.Name, err)
+		return true
+	}
+	return ok
 }
 
 // runTeamAgents executes all agents in a team for a phase
@@ -827,10 +1840,16 @@ func (tc *TeamCollaboration) runTeamAgents(team Team, phase Phase, initialPrompt
 
 	// Sequential execution within team
 	for _, agent := range team.Agents {
+		if !tc.agentParticipates(agent, phase) {
+			continue
+		}
+		tc.waitForAgentDeps(agent, phase)
+
 		prompt := tc.buildTeamAgentPrompt(agent, team, phase, initialPrompt)
-		response := AI(prompt, WithConcise())
+		response := AI(prompt, teamAgentOptions(phase)...)
 
 		results[agent.Name] = response
+		tc.setSharedKnowledge(agent.Name, response)
 
 		if tc.OnAgentResponse != nil {
 			tc.OnAgentResponse(agent.Name, response)
@@ -854,19 +1873,26 @@ func (tc *TeamCollaboration) runParallelTeamAgents(team Team, phase Phase, initi
 	semaphore := make(chan struct{}, maxConcurrency)
 
 	for _, agent := range team.Agents {
+		if !tc.agentParticipates(agent, phase) {
+			continue
+		}
+
 		wg.Add(1)
 		go func(agent Agent) {
 			defer wg.Done()
 
+			tc.waitForAgentDeps(agent, phase)
+
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
 			prompt := tc.buildTeamAgentPrompt(agent, team, phase, initialPrompt)
-			response := AI(prompt, WithConcise())
+			response := AI(prompt, teamAgentOptions(phase)...)
 
 			mu.Lock()
 			results[agent.Name] = response
 			mu.Unlock()
+			tc.setSharedKnowledge(agent.Name, response)
 
 			if tc.OnAgentResponse != nil {
 				tc.OnAgentResponse(agent.Name, response)
@@ -887,10 +1913,12 @@ func (tc *TeamCollaboration) buildTeamAgentPrompt(agent Agent, team Team, phase
 	prompt += fmt.Sprintf("PHASE: %s\n%s\n\n", phase.Name, phase.Description)
 	prompt += fmt.Sprintf("ORIGINAL CHALLENGE: %s\n\n", initialPrompt)
 
+	sharedKnowledge := tc.knowledgeSnapshot()
+
 	// Add shared knowledge from other teams/agents
-	if len(tc.SharedKnowledge) > 0 {
+	if len(sharedKnowledge) > 0 {
 		prompt += "COLLABORATIVE INSIGHTS FROM OTHER TEAMS:\n"
-		for name, knowledge := range tc.SharedKnowledge {
+		for name, knowledge := range sharedKnowledge {
 			if name != agent.Name {
 				prompt += fmt.Sprintf("🔹 %s: %s\n", name, knowledge)
 			}
@@ -898,10 +1926,20 @@ func (tc *TeamCollaboration) buildTeamAgentPrompt(agent Agent, team Team, phase
 		prompt += "\n"
 	}
 
-	prompt += phase.PromptTemplate
+	prompt += renderPromptTemplate(phase.PromptTemplate, initialPrompt, sharedKnowledge)
 	return prompt
 }
 
+// teamAgentOptions builds the request options for a team agent's turn,
+// sharing phase.Attachments (if any) with every agent in the phase.
+func teamAgentOptions(phase Phase) []Option {
+	opts := []Option{WithConcise()}
+	if len(phase.Attachments) > 0 {
+		opts = append(opts, WithImages(phase.Attachments...))
+	}
+	return opts
+}
+
 // ===== CONVENIENCE FUNCTIONS FOR TEAM COLLABORATIONS =====
 
 // TeamDevCollab creates a development collaboration between SW and Testing teams
@@ -983,6 +2021,8 @@ func TeamDevCollab(swTeam, testTeam []Agent, project string) (map[string]map[str
 		fmt.Printf("🤖 %s contributed!\n", agentName)
 	}
 
+	attachDefaultSummarySink(collab)
+
 	return collab.Run(project)
 }
 
@@ -1014,5 +2054,19 @@ func QuickTeamCollab(teams []Team, phases []string, challenge string) (map[strin
 		fmt.Printf("🤖 %s contributed to the discussion\n", agentName)
 	}
 
+	attachDefaultSummarySink(collab)
+
 	return collab.Run(challenge)
 }
+
+// attachDefaultSummarySink mounts a MarkdownSummarySink on collab when the
+// BREEZE_SUMMARY_FILE env var is set, so TeamDevCollab/QuickTeamCollab runs
+// produce a browsable report file without every caller wiring one up by
+// hand.
+func attachDefaultSummarySink(collab *TeamCollaboration) {
+	path := os.Getenv("BREEZE_SUMMARY_FILE")
+	if path == "" {
+		return
+	}
+	collab.ArtifactSinks = append(collab.ArtifactSinks, MarkdownSummarySink(path))
+}
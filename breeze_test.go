@@ -98,7 +98,7 @@ func TestCollaborationBuildAgentPrompt(t *testing.T) {
 		PromptTemplate: "Please test this",
 	}
 
-	prompt := collab.buildAgentPrompt(agent, phase, "Build a feature")
+	prompt := collab.BuildAgentPrompt(agent, phase, "Build a feature")
 
 	if !strings.Contains(prompt, "Alice") {
 		t.Error("Prompt should contain agent name")
@@ -324,6 +324,19 @@ func TestProcessDocuments_EmptyList(t *testing.T) {
 	}
 }
 
+func TestOllamaBaseURL_DefaultsToDefaultClient(t *testing.T) {
+	if got := ollamaBaseURL(RequestOptions{}); got != defaultClient.ollamaURL {
+		t.Errorf("Expected no Provider set to fall back to defaultClient.ollamaURL (%q), got %q", defaultClient.ollamaURL, got)
+	}
+}
+
+func TestOllamaBaseURL_HonorsOllamaProviderBaseURL(t *testing.T) {
+	options := RequestOptions{Provider: OllamaProvider{BaseURL: "http://example.invalid:1234"}}
+	if got := ollamaBaseURL(options); got != "http://example.invalid:1234" {
+		t.Errorf("Expected WithProvider(OllamaProvider{BaseURL: ...}) to override the endpoint, got %q", got)
+	}
+}
+
 func TestWithTemp_Validation(t *testing.T) {
 	// Test that temperature is set correctly
 	opts := RequestOptions{}
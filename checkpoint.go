@@ -0,0 +1,161 @@
+package breeze
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// CollabState is what a Checkpointer persists between TeamCollaboration.Run
+// calls: every completed phase's results (keyed by Phase.Name, standing in
+// for "index of the next phase to execute" now that Run schedules Phases as
+// a DAG rather than a strict sequence - see Phase.DependsOn), the
+// SharedKnowledge accumulated so far, and a hash of the spec that produced
+// it so Run can refuse to resume against a pipeline that has since changed.
+type CollabState struct {
+	SpecHash        string                       `json:"spec_hash"`
+	Results         map[string]map[string]string `json:"results"`
+	SharedKnowledge map[string]string            `json:"shared_knowledge"`
+}
+
+// Checkpointer persists and reloads a TeamCollaboration's CollabState, so a
+// run interrupted by a network failure, rate limit, or panic can resume
+// instead of re-running (and re-paying for) every already-completed phase.
+type Checkpointer interface {
+	Save(state CollabState) error
+	Load() (CollabState, error)
+}
+
+// ErrNoCheckpoint is returned by Load when no checkpoint is available yet,
+// signaling Run to start fresh instead of failing.
+var ErrNoCheckpoint = errors.New("breeze: no checkpoint available")
+
+// NoopCheckpointer discards Save and always reports no checkpoint
+// available, the default Checkpointer for a TeamCollaboration that isn't
+// configured to persist state.
+type NoopCheckpointer struct{}
+
+// Save implements Checkpointer by doing nothing.
+func (NoopCheckpointer) Save(CollabState) error { return nil }
+
+// Load implements Checkpointer by always reporting no checkpoint available.
+func (NoopCheckpointer) Load() (CollabState, error) { return CollabState{}, ErrNoCheckpoint }
+
+// fileCheckpointer persists CollabState as a single JSON file.
+type fileCheckpointer struct {
+	path string
+}
+
+// FileCheckpointer returns a Checkpointer that saves CollabState as JSON to
+// path, overwriting it on every Save, and reads it back on Load. A missing
+// file is reported as ErrNoCheckpoint rather than a read error.
+func FileCheckpointer(path string) Checkpointer {
+	return fileCheckpointer{path: path}
+}
+
+// Save implements Checkpointer.
+func (f fileCheckpointer) Save(state CollabState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("file checkpointer: marshal state: %w", err)
+	}
+	if err := os.WriteFile(f.path, data, 0644); err != nil {
+		return fmt.Errorf("file checkpointer: write %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Load implements Checkpointer.
+func (f fileCheckpointer) Load() (CollabState, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return CollabState{}, ErrNoCheckpoint
+		}
+		return CollabState{}, fmt.Errorf("file checkpointer: read %s: %w", f.path, err)
+	}
+	var state CollabState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return CollabState{}, fmt.Errorf("file checkpointer: parse %s: %w", f.path, err)
+	}
+	return state, nil
+}
+
+// phaseFingerprint captures the parts of a Phase that define its identity
+// for specHash purposes. PostStep (middleware funcs) and Verifier aren't
+// included since neither is JSON-marshalable; a pipeline that only changes
+// those won't be caught by the spec-hash mismatch check.
+type phaseFingerprint struct {
+	Name           string
+	PromptTemplate string
+	IsParallel     bool
+	MaxConcurrency int
+	Condition      string
+	DependsOn      []string
+	AgentDependsOn map[string][]string
+}
+
+// agentFingerprint captures the parts of an Agent that define its identity
+// for specHash purposes. Tools (which carry a Go func) isn't JSON-
+// marshalable and is excluded for the same reason as Phase.PostStep above.
+type agentFingerprint struct {
+	Name        string
+	Role        string
+	Expertise   string
+	Personality string
+	Model       string
+	When        string
+}
+
+// teamFingerprint captures a Team's identity for specHash purposes.
+type teamFingerprint struct {
+	Name        string
+	Description string
+	Agents      []agentFingerprint
+}
+
+// specHash fingerprints tc's Teams and Phases so Run can detect a
+// checkpoint written against a different pipeline and refuse to resume
+// against it silently.
+func (tc *TeamCollaboration) specHash() string {
+	teams := make([]teamFingerprint, len(tc.Teams))
+	for i, team := range tc.Teams {
+		agents := make([]agentFingerprint, len(team.Agents))
+		for j, agent := range team.Agents {
+			agents[j] = agentFingerprint{
+				Name:        agent.Name,
+				Role:        agent.Role,
+				Expertise:   agent.Expertise,
+				Personality: agent.Personality,
+				Model:       agent.Model,
+				When:        agent.When,
+			}
+		}
+		teams[i] = teamFingerprint{Name: team.Name, Description: team.Description, Agents: agents}
+	}
+
+	phases := make([]phaseFingerprint, len(tc.Phases))
+	for i, phase := range tc.Phases {
+		phases[i] = phaseFingerprint{
+			Name:           phase.Name,
+			PromptTemplate: phase.PromptTemplate,
+			IsParallel:     phase.IsParallel,
+			MaxConcurrency: phase.MaxConcurrency,
+			Condition:      phase.Condition,
+			DependsOn:      phase.DependsOn,
+			AgentDependsOn: phase.AgentDependsOn,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Teams  []teamFingerprint
+		Phases []phaseFingerprint
+	}{teams, phases})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%x", sum)
+}
@@ -0,0 +1,130 @@
+package breeze
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestNoopCheckpointer(t *testing.T) {
+	var cp Checkpointer = NoopCheckpointer{}
+	if err := cp.Save(CollabState{SpecHash: "abc"}); err != nil {
+		t.Errorf("Expected NoopCheckpointer.Save to succeed, got %v", err)
+	}
+	if _, err := cp.Load(); !errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("Expected NoopCheckpointer.Load to return ErrNoCheckpoint, got %v", err)
+	}
+}
+
+func TestFileCheckpointer_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	cp := FileCheckpointer(path)
+
+	if _, err := cp.Load(); !errors.Is(err, ErrNoCheckpoint) {
+		t.Fatalf("Expected Load of a missing file to return ErrNoCheckpoint, got %v", err)
+	}
+
+	state := CollabState{
+		SpecHash:        "deadbeef",
+		Results:         map[string]map[string]string{"Requirements": {"Alice": "done"}},
+		SharedKnowledge: map[string]string{"Alice": "done"},
+	}
+	if err := cp.Save(state); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := cp.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.SpecHash != state.SpecHash || loaded.Results["Requirements"]["Alice"] != "done" {
+		t.Errorf("Expected loaded state to match saved state, got %+v", loaded)
+	}
+}
+
+func TestSpecHash_StableAndSensitiveToPhases(t *testing.T) {
+	teams := []Team{{Name: "SW", Agents: []Agent{{Name: "Alice", Role: "Dev"}}}}
+	phasesA := []Phase{{Name: "Requirements", PromptTemplate: "go"}}
+	phasesB := []Phase{{Name: "Requirements", PromptTemplate: "go, but differently"}}
+
+	tcA1 := NewTeamCollaboration(teams, phasesA)
+	tcA2 := NewTeamCollaboration(teams, phasesA)
+	tcB := NewTeamCollaboration(teams, phasesB)
+
+	if tcA1.specHash() != tcA2.specHash() {
+		t.Error("Expected specHash to be stable across identical specs")
+	}
+	if tcA1.specHash() == tcB.specHash() {
+		t.Error("Expected specHash to differ when a phase's PromptTemplate changes")
+	}
+}
+
+// recordingCheckpointer wraps FileCheckpointer but also appends a deep copy
+// of every Save call's Results to Saves, so a test can inspect how many
+// times Run checkpointed and what each snapshot contained.
+type recordingCheckpointer struct {
+	Checkpointer
+	mu    sync.Mutex
+	Saves []map[string]map[string]string
+}
+
+func (r *recordingCheckpointer) Save(state CollabState) error {
+	r.mu.Lock()
+	snapshot := make(map[string]map[string]string, len(state.Results))
+	for name, phaseResults := range state.Results {
+		snapshot[name] = phaseResults
+	}
+	r.Saves = append(r.Saves, snapshot)
+	r.mu.Unlock()
+	return r.Checkpointer.Save(state)
+}
+
+// TestRun_CheckspointsOncePerLayerNotPerPhase guards against the race where
+// every phase in a concurrent DAG layer independently snapshots results and
+// calls Checkpointer.Save: with no ordering between goroutines, a phase that
+// snapshotted before a sibling finished could write its stale, less-complete
+// checkpoint after the sibling's more-complete one, regressing the on-disk
+// state. Run now saves once per layer, after wg.Wait(), so SW Implementation
+// and Testing - concurrent siblings in the same layer - produce exactly one
+// Save between them, and that Save's snapshot already has both.
+func TestRun_CheckspointsOncePerLayerNotPerPhase(t *testing.T) {
+	recorder := &recordingCheckpointer{Checkpointer: FileCheckpointer(filepath.Join(t.TempDir(), "state.json"))}
+
+	phases := []Phase{
+		{Name: "Requirements"},
+		{Name: "SW Implementation", DependsOn: []string{"Requirements"}},
+		{Name: "Testing", DependsOn: []string{"Requirements"}},
+		{Name: "Final Polish", DependsOn: []string{"SW Implementation", "Testing"}},
+	}
+	tc := NewTeamCollaboration(nil, phases, WithCheckpointer(recorder))
+
+	if _, err := tc.Run("project"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(recorder.Saves) != 3 {
+		t.Fatalf("Expected 3 layer saves (Requirements; SW Implementation+Testing; Final Polish), got %d: %+v", len(recorder.Saves), recorder.Saves)
+	}
+
+	concurrentLayerSave := recorder.Saves[1]
+	if _, ok := concurrentLayerSave["SW Implementation"]; !ok {
+		t.Errorf("Expected the concurrent layer's save to include SW Implementation, got %+v", concurrentLayerSave)
+	}
+	if _, ok := concurrentLayerSave["Testing"]; !ok {
+		t.Errorf("Expected the concurrent layer's save to include Testing, got %+v", concurrentLayerSave)
+	}
+}
+
+func TestRun_RejectsMismatchedCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	cp := FileCheckpointer(path)
+	if err := cp.Save(CollabState{SpecHash: "stale-hash"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tc := NewTeamCollaboration(nil, []Phase{{Name: "Requirements", PromptTemplate: "go"}}, WithCheckpointer(cp))
+	if _, err := tc.Run("project"); err == nil {
+		t.Error("Expected Run to reject a checkpoint whose spec hash doesn't match")
+	}
+}
@@ -15,6 +15,8 @@ func main() {
 		fmt.Println("       breeze chat <prompt>")
 		fmt.Println("       breeze code <prompt>")
 		fmt.Println("       breeze clear")
+		fmt.Println("       breeze run <collab.yaml> --input \"<prompt>\" [--resume]")
+		fmt.Println("       breeze support inspect <bundle.zip>")
 		return
 	}
 
@@ -36,6 +38,10 @@ func main() {
 	case "clear":
 		breeze.Clear()
 		fmt.Println("Conversation cleared.")
+	case "run":
+		runCollaborationSpec(args[1:])
+	case "support":
+		runSupport(args[1:])
 	default:
 		// Default to ai
 		prompt := strings.Join(args, " ")
@@ -43,3 +49,75 @@ func main() {
 		fmt.Println(response)
 	}
 }
+
+// runSupport handles `breeze support inspect <bundle.zip>`, printing a
+// tabular summary of a breeze.Collaboration.ExportSupportBundle output.
+func runSupport(args []string) {
+	if len(args) < 2 || args[0] != "inspect" {
+		fmt.Println("Usage: breeze support inspect <bundle.zip>")
+		return
+	}
+	summary, err := breeze.InspectSupportBundle(args[1])
+	if err != nil {
+		fmt.Printf("Failed to inspect %s: %v\n", args[1], err)
+		os.Exit(1)
+	}
+	fmt.Println(summary)
+}
+
+// runCollaborationSpec handles `breeze run <path> --input "<prompt>" [--resume]`:
+// it loads path as a breeze.CollaborationSpec and runs it against the given
+// input, printing every phase's results. Every run checkpoints to
+// <path>.checkpoint.json; --resume picks that checkpoint back up instead of
+// starting over, and its absence discards any stale checkpoint first so a
+// forgotten --resume can't silently replay an old run.
+func runCollaborationSpec(args []string) {
+	if len(args) < 1 {
+		fmt.Println("Usage: breeze run <collab.yaml> --input \"<prompt>\" [--resume]")
+		return
+	}
+	path := args[0]
+
+	var input string
+	resume := false
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--input":
+			if i+1 < len(args) {
+				input = args[i+1]
+				i++
+			}
+		case "--resume":
+			resume = true
+		}
+	}
+	if input == "" {
+		fmt.Println("Usage: breeze run <collab.yaml> --input \"<prompt>\" [--resume]")
+		return
+	}
+
+	tc, err := breeze.LoadCollaborationSpec(path)
+	if err != nil {
+		fmt.Printf("Failed to load %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	checkpointPath := path + ".checkpoint.json"
+	if !resume {
+		os.Remove(checkpointPath)
+	}
+	tc.Checkpointer = breeze.FileCheckpointer(checkpointPath)
+
+	results, err := tc.Run(input)
+	if err != nil {
+		fmt.Printf("Collaboration run failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for phaseName, phaseResults := range results {
+		fmt.Printf("## Phase: %s\n\n", phaseName)
+		for agentName, response := range phaseResults {
+			fmt.Printf("### %s\n%s\n\n", agentName, response)
+		}
+	}
+}
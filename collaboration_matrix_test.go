@@ -0,0 +1,192 @@
+//go:build collabmatrix
+
+package breeze
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// matrixMockProvider is a deterministic Provider for TestCollaborationMethodMatrix:
+// every Generate call echoes back a short, prompt-derived string instead of
+// calling a real model, so the matrix is reproducible and fast.
+type matrixMockProvider struct{}
+
+func (matrixMockProvider) Generate(_ context.Context, _ RequestOptions, prompt string) (string, error) {
+	return fmt.Sprintf("response[%d chars]", len(prompt)), nil
+}
+
+func (matrixMockProvider) GenerateStream(_ context.Context, _ RequestOptions, prompt string) (<-chan Token, func() error) {
+	ch := make(chan Token, 1)
+	ch <- Token{Text: prompt}
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+func (matrixMockProvider) Embed(_ context.Context, _ RequestOptions, _ string) ([]float32, error) {
+	return nil, fmt.Errorf("matrixMockProvider: Embed not supported")
+}
+
+func (matrixMockProvider) ListModels(_ context.Context) ([]string, error) {
+	return []string{"matrix-mock"}, nil
+}
+
+// matrixAgents mirrors RunWebAppTruckSimulation's five-agent web dev team,
+// the richest agent roster in this repo's examples, trimmed to n agents so
+// the matrix can also exercise smaller rosters.
+func matrixAgents(n int) []Agent {
+	all := []Agent{
+		{Name: "Alice", Role: "UX/UI Designer"},
+		{Name: "Bob", Role: "Frontend Developer"},
+		{Name: "Carol", Role: "Data Architect"},
+		{Name: "Dave", Role: "DevOps Engineer"},
+		{Name: "Eve", Role: "Product Manager"},
+	}
+	if n > len(all) {
+		n = len(all)
+	}
+	return all[:n]
+}
+
+// matrixPhases builds a two-phase pipeline using method for both phases, so
+// "SharedKnowledge is monotonically growing" can be checked across a phase
+// boundary, matching RunWebAppTruckSimulation's pattern of feeding one
+// phase's results into the next.
+func matrixPhases(method CollaborationMethod) []Phase {
+	return []Phase{
+		{Name: "Design", Description: "design phase", PromptTemplate: "Design the solution.", Method: method},
+		{Name: "Build", Description: "build phase", PromptTemplate: "Build on the design.", Method: method},
+	}
+}
+
+// methodCase names one CollaborationMethod combination under test.
+type methodCase struct {
+	label  string
+	method CollaborationMethod
+}
+
+func matrixCases() []methodCase {
+	return []methodCase{
+		{"Sequential", Sequential()},
+		{"Parallel(1)", Parallel(1)},
+		{"Parallel(3)", Parallel(3)},
+		{"PeerReview(1)", PeerReview(1)},
+		{"PeerReview(3)", PeerReview(3)},
+		{"Consensus(1)", Consensus(1)},
+		{"Consensus(3)", Consensus(3)},
+		{"DebateStyle(1)", DebateStyle(1)},
+		{"DebateStyle(3)", DebateStyle(3)},
+	}
+}
+
+// assertMethodInvariants checks the results a method produced against the
+// invariants that hold for that method's key-naming scheme, failing t if
+// violated. It returns true (pass) or false (fail) for the matrix report.
+func assertMethodInvariants(t *testing.T, label string, agents []Agent, results map[string]string) bool {
+	t.Helper()
+	ok := true
+
+	switch {
+	case strings.HasPrefix(label, "DebateStyle"):
+		// Every agent debates every round, keyed "<agent>_<position>_round_<n>".
+		for _, agent := range agents {
+			found := false
+			for key := range results {
+				if strings.HasPrefix(key, agent.Name+"_") {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s: expected at least one debate entry for agent %s", label, agent.Name)
+				ok = false
+			}
+		}
+	default:
+		// Sequential/Parallel/PeerReview/Consensus all key each agent's own
+		// contribution under its plain Name - "every agent has a result key".
+		for _, agent := range agents {
+			if _, present := results[agent.Name]; !present {
+				t.Errorf("%s: missing result key for agent %s", label, agent.Name)
+				ok = false
+			}
+		}
+		if strings.HasPrefix(label, "PeerReview") {
+			for _, agent := range agents {
+				if _, present := results[agent.Name+"_review"]; !present {
+					t.Errorf("%s: missing review key for agent %s", label, agent.Name)
+					ok = false
+				}
+			}
+		}
+		if strings.HasPrefix(label, "Consensus") {
+			if _, present := results["CONSENSUS"]; !present {
+				t.Errorf("%s: expected a CONSENSUS key after Consensus", label)
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// TestCollaborationMethodMatrix exhaustively runs every built-in
+// CollaborationMethod combination against a deterministic mock AI, at two
+// agent-count rosters, asserting each method's key-naming invariants and
+// that SharedKnowledge only grows phase-over-phase (never loses an entry).
+// Run with -tags=collabmatrix; it's gated out of the default test run since
+// it isn't exercising anything the default build needs to catch on every
+// commit, just a regression net for when a new method/option is added.
+func TestCollaborationMethodMatrix(t *testing.T) {
+	SetDefaultProvider(matrixMockProvider{})
+	defer SetDefaultProvider(OllamaProvider{})
+
+	type cell struct {
+		agents int
+		pass   bool
+	}
+	report := make(map[string][]cell)
+
+	for _, tc := range matrixCases() {
+		for _, agentCount := range []int{2, 5} {
+			agents := matrixAgents(agentCount)
+			collab := NewCollaboration(agents, matrixPhases(tc.method))
+
+			t.Run(fmt.Sprintf("%s/%d_agents", tc.label, agentCount), func(t *testing.T) {
+				prevSize := 0
+				collab.OnPhaseComplete = func(_ string, _ map[string]string) {
+					size := len(collab.SharedKnowledge)
+					if size < prevSize {
+						t.Errorf("%s: SharedKnowledge shrank from %d to %d entries between phases", tc.label, prevSize, size)
+					}
+					prevSize = size
+				}
+
+				results, err := collab.Run("Build a minimal feature")
+				if err != nil {
+					t.Fatalf("%s: Run failed: %v", tc.label, err)
+				}
+
+				pass := true
+				for _, phaseResults := range results {
+					if !assertMethodInvariants(t, tc.label, agents, phaseResults) {
+						pass = false
+					}
+				}
+				report[tc.label] = append(report[tc.label], cell{agents: agentCount, pass: pass})
+			})
+		}
+	}
+
+	t.Log("CollaborationMethod matrix report:")
+	for _, tc := range matrixCases() {
+		for _, c := range report[tc.label] {
+			status := "PASS"
+			if !c.pass {
+				status = "FAIL"
+			}
+			t.Logf("  %-16s agents=%d %s", tc.label, c.agents, status)
+		}
+	}
+}
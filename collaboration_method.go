@@ -0,0 +1,422 @@
+package breeze
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CollaborationMethod defines how a phase's participating agents work
+// together: given the agents, the Collaboration they belong to (for
+// SharedKnowledge and BuildAgentPrompt), the Phase being run, and the
+// initial prompt, it returns each agent's (or synthesized) response keyed
+// by name. Assign one to Phase.Method to take over a phase's execution
+// from the built-in IsParallel/MaxConcurrency dispatch. See Sequential,
+// Parallel, PeerReview, Consensus, DebateStyle, and CoderStrategy.
+type CollaborationMethod func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string
+
+// policyAI is the single choke point every built-in CollaborationMethod
+// calls through instead of invoking the model directly: collab.Policy (if
+// set) is consulted once before the call, against prompt, and once after,
+// against the response, both keyed by object (the SharedKnowledge entry this
+// call is about to produce). A PolicyDeny on either side short-circuits to a
+// fixed denial message instead of reaching the model / returning its output.
+// Once the read check passes, the turn itself goes through the same
+// tools/verify/poststep/provider-routing pipeline runPhase's sequential path
+// and runParallelPhase use - runAgentTurnWithTools, then verifyAndRepair,
+// then runPostStep - so a Method-driven phase honors Agent.Provider/
+// Backend/Tools, image Attachments, Phase.Verifier, and Phase.PostStep just
+// like the built-in dispatch does. initialPrompt is the phase's original
+// prompt (as opposed to prompt, the fully-built per-call turn prompt),
+// needed by verifyAndRepair to re-check a repaired answer against the real
+// question. The call is also timed and, if collab.Recorder is set, appended
+// as a CallRecord for ExportSupportBundle.
+func policyAI(collab *Collaboration, agent Agent, phase Phase, object string, initialPrompt string, prompt string) string {
+	start := time.Now()
+
+	prompt, ok := checkPolicy(collab, PolicyRequest{Agent: agent, Phase: phase, Object: object, Action: PolicyRead, Text: prompt})
+	if !ok {
+		return policyDeniedResponse(object)
+	}
+
+	response := collab.runAgentTurnWithTools(agent, prompt, phase.Attachments...)
+	response = collab.verifyAndRepair(agent, phase, initialPrompt, response)
+	response = collab.runPostStep(agent, phase, response)
+
+	response, ok = checkPolicy(collab, PolicyRequest{Agent: agent, Phase: phase, Object: object, Action: PolicyWrite, Text: response})
+	if !ok {
+		return policyDeniedResponse(object)
+	}
+
+	if collab.Recorder != nil {
+		collab.Recorder.record(CallRecord{
+			PhaseName: phase.Name,
+			AgentName: agent.Name,
+			Object:    object,
+			Prompt:    prompt,
+			Response:  response,
+			Duration:  time.Since(start),
+			Tokens:    len(prompt)/4 + len(response)/4, // rough estimate, the same convention used elsewhere in this package
+		})
+	}
+	return response
+}
+
+// policyDeniedResponse is the placeholder recorded for object when Policy
+// denies a call, so a denied agent still has a results/SharedKnowledge
+// entry rather than an empty string that could be mistaken for "no
+// response".
+func policyDeniedResponse(object string) string {
+	return fmt.Sprintf("[blocked by policy: %s]", object)
+}
+
+// Sequential runs agents one after another, each seeing the prior agents'
+// responses (via collab.SharedKnowledge, updated as it goes) before
+// producing its own.
+func Sequential() CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		results := make(map[string]string)
+
+		for _, agent := range agents {
+			prompt := collab.BuildAgentPrompt(agent, phase, initialPrompt)
+			response := policyAI(collab, agent, phase, agent.Name, initialPrompt, prompt)
+
+			results[agent.Name] = response
+			collab.SharedKnowledge[agent.Name] = response
+
+			if collab.OnAgentResponse != nil {
+				collab.OnAgentResponse(agent.Name, response)
+			}
+		}
+
+		return results
+	}
+}
+
+// Parallel runs all agents simultaneously, bounded by maxConcurrency (0 or
+// negative means unbounded, one goroutine per agent).
+func Parallel(maxConcurrency int) CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		results := make(map[string]string)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+
+		limit := maxConcurrency
+		if limit <= 0 {
+			limit = len(agents)
+		}
+		semaphore := make(chan struct{}, limit)
+
+		for _, agent := range agents {
+			wg.Add(1)
+			go func(agent Agent) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				prompt := collab.BuildAgentPrompt(agent, phase, initialPrompt)
+				response := policyAI(collab, agent, phase, agent.Name, initialPrompt, prompt)
+
+				mu.Lock()
+				results[agent.Name] = response
+				mu.Unlock()
+
+				if collab.OnAgentResponse != nil {
+					collab.OnAgentResponse(agent.Name, response)
+				}
+			}(agent)
+		}
+
+		wg.Wait()
+		return results
+	}
+}
+
+// PeerReview runs agents in parallel (bounded by maxConcurrency), then has
+// every agent review the others' work; a reviewer's feedback is recorded
+// under "<agent>_review" alongside its initial contribution.
+func PeerReview(maxConcurrency int) CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		results := Parallel(maxConcurrency)(agents, collab, phase, initialPrompt)
+
+		reviews := make(map[string]string)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+
+		for _, reviewer := range agents {
+			wg.Add(1)
+			go func(reviewer Agent) {
+				defer wg.Done()
+
+				var prompt strings.Builder
+				fmt.Fprintf(&prompt, "You are %s reviewing peer work. Original challenge: %s\n\nPEER CONTRIBUTIONS:\n", reviewer.Name, initialPrompt)
+				for _, agent := range agents {
+					if agent.Name == reviewer.Name {
+						continue
+					}
+					fmt.Fprintf(&prompt, "- %s: %s\n", agent.Name, results[agent.Name])
+				}
+				prompt.WriteString("\nProvide constructive feedback, strengths, weaknesses, and suggestions:")
+
+				review := policyAI(collab, reviewer, phase, reviewer.Name+"_review", initialPrompt, prompt.String())
+
+				mu.Lock()
+				reviews[reviewer.Name+"_review"] = review
+				mu.Unlock()
+			}(reviewer)
+		}
+
+		wg.Wait()
+		for name, review := range reviews {
+			results[name] = review
+		}
+		return results
+	}
+}
+
+// Consensus runs agents in parallel for individual perspectives, then
+// synthesizes them into a single "CONSENSUS" entry alongside each agent's
+// own contribution.
+func Consensus(maxConcurrency int) CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		results := Parallel(maxConcurrency)(agents, collab, phase, initialPrompt)
+
+		var prompt strings.Builder
+		fmt.Fprintf(&prompt, "Challenge: %s\n\nEXPERT OPINIONS:\n", initialPrompt)
+		for _, agent := range agents {
+			fmt.Fprintf(&prompt, "- %s: %s\n", agent.Name, results[agent.Name])
+		}
+		prompt.WriteString("\nSynthesize these expert opinions into a unified consensus:")
+
+		synthesizer := Agent{Name: "CONSENSUS", Role: "synthesizer"}
+		results["CONSENSUS"] = policyAI(collab, synthesizer, phase, "CONSENSUS", initialPrompt, prompt.String())
+		return results
+	}
+}
+
+// DebateStyle runs rounds debate rounds where agents alternate between
+// advocating for and challenging initialPrompt, each round seeing the
+// previous round's points. Results are keyed "<agent>_<position>_round_<n>".
+func DebateStyle(rounds int) CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		results := make(map[string]string)
+
+		for round := 1; round <= rounds; round++ {
+			for i, agent := range agents {
+				position := "advocate"
+				if i%2 == 1 {
+					position = "challenge"
+				}
+
+				var prompt strings.Builder
+				fmt.Fprintf(&prompt, "You are %s in a structured debate. Your position: %s the proposal.\n", agent.Name, position)
+				fmt.Fprintf(&prompt, "Challenge: %s\n\n", initialPrompt)
+
+				if round > 1 {
+					prompt.WriteString("PREVIOUS DEBATE POINTS:\n")
+					for key, point := range results {
+						if strings.Contains(key, fmt.Sprintf("round_%d", round-1)) {
+							fmt.Fprintf(&prompt, "- %s\n", point)
+						}
+					}
+				}
+				fmt.Fprintf(&prompt, "Provide a strong %s argument:", position)
+
+				object := fmt.Sprintf("%s_%s_round_%d", agent.Name, position, round)
+				response := policyAI(collab, agent, phase, object, initialPrompt, prompt.String())
+				results[object] = response
+			}
+		}
+
+		return results
+	}
+}
+
+// CoderMode selects which coding tack CoderStrategy takes.
+type CoderMode int
+
+const (
+	// BottomUp has every agent but the last write a leaf component in
+	// parallel; the last agent then integrates them into a whole.
+	BottomUp CoderMode = iota
+	// TopDown has the first agent produce an outline/interfaces; the
+	// remaining agents then each fill in a section in parallel.
+	TopDown
+)
+
+// CoderArtifactsKey is the Collaboration.SharedKnowledge key CoderStrategy
+// stashes its collected map[filename]content under (JSON-encoded, since
+// SharedKnowledge is map[string]string), for downstream phases to read with
+// DecodeCoderArtifacts and write out as a real project tree.
+const CoderArtifactsKey = "__coder_artifacts__"
+
+// CoderStrategy builds a CollaborationMethod that alternates between two
+// coding tacks depending on mode (see BottomUp, TopDown). Every agent
+// response is scanned for fenced code blocks immediately preceded by a
+// "**dir/file.ext:**" header line (see extractFencedCodeBlocks); the
+// collected filename->content map is merged into
+// Collaboration.SharedKnowledge[CoderArtifactsKey] so a later phase can
+// patch or review the resulting files.
+func CoderStrategy(mode CoderMode) CollaborationMethod {
+	return func(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) map[string]string {
+		if len(agents) == 0 {
+			return map[string]string{}
+		}
+
+		var results map[string]string
+		var artifacts map[string]string
+		if mode == TopDown {
+			results, artifacts = runTopDownCoding(agents, collab, phase, initialPrompt)
+		} else {
+			results, artifacts = runBottomUpCoding(agents, collab, phase, initialPrompt)
+		}
+
+		mergeCoderArtifacts(collab, artifacts)
+		return results
+	}
+}
+
+// runBottomUpCoding has every agent but the last write a leaf component in
+// parallel, then hands the last ("integrator") agent every leaf's output to
+// compose into a whole.
+func runBottomUpCoding(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) (map[string]string, map[string]string) {
+	if len(agents) == 1 {
+		response := policyAI(collab, agents[0], phase, agents[0].Name, initialPrompt, collab.BuildAgentPrompt(agents[0], phase, initialPrompt))
+		return map[string]string{agents[0].Name: response}, extractFencedCodeBlocks(response)
+	}
+
+	leaves, integrator := agents[:len(agents)-1], agents[len(agents)-1]
+	results := Parallel(0)(leaves, collab, phase, initialPrompt)
+
+	artifacts := make(map[string]string)
+	var prompt strings.Builder
+	prompt.WriteString(collab.BuildAgentPrompt(integrator, phase, initialPrompt))
+	prompt.WriteString("\n\nLEAF COMPONENTS TO INTEGRATE:\n")
+	for _, leaf := range leaves {
+		fmt.Fprintf(&prompt, "\n**%s:**\n%s\n", leaf.Name, results[leaf.Name])
+		for name, content := range extractFencedCodeBlocks(results[leaf.Name]) {
+			artifacts[name] = content
+		}
+	}
+
+	integrated := policyAI(collab, integrator, phase, integrator.Name, initialPrompt, prompt.String())
+	results[integrator.Name] = integrated
+	for name, content := range extractFencedCodeBlocks(integrated) {
+		artifacts[name] = content
+	}
+
+	return results, artifacts
+}
+
+// runTopDownCoding has the first ("lead") agent produce an outline/
+// interfaces, then hands every remaining agent that outline to fill in
+// their own section in parallel.
+func runTopDownCoding(agents []Agent, collab *Collaboration, phase Phase, initialPrompt string) (map[string]string, map[string]string) {
+	lead := agents[0]
+	outline := policyAI(collab, lead, phase, lead.Name, initialPrompt, collab.BuildAgentPrompt(lead, phase, initialPrompt))
+
+	results := map[string]string{lead.Name: outline}
+	artifacts := extractFencedCodeBlocks(outline)
+
+	peers := agents[1:]
+	if len(peers) == 0 {
+		return results, artifacts
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peer Agent) {
+			defer wg.Done()
+
+			var prompt strings.Builder
+			prompt.WriteString(collab.BuildAgentPrompt(peer, phase, initialPrompt))
+			fmt.Fprintf(&prompt, "\n\nOUTLINE FROM %s:\n%s\n\nImplement your section of this design.", lead.Name, outline)
+
+			response := policyAI(collab, peer, phase, peer.Name, initialPrompt, prompt.String())
+
+			mu.Lock()
+			results[peer.Name] = response
+			for name, content := range extractFencedCodeBlocks(response) {
+				artifacts[name] = content
+			}
+			mu.Unlock()
+		}(peer)
+	}
+	wg.Wait()
+
+	return results, artifacts
+}
+
+// mergeCoderArtifacts JSON-encodes artifacts merged with whatever was
+// already stashed at collab.SharedKnowledge[CoderArtifactsKey] (a later
+// agent's file wins on conflict) and writes the result back.
+func mergeCoderArtifacts(collab *Collaboration, artifacts map[string]string) {
+	merged, err := DecodeCoderArtifacts(collab.SharedKnowledge)
+	if err != nil {
+		merged = make(map[string]string)
+	}
+	for name, content := range artifacts {
+		merged[name] = content
+	}
+
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		fmt.Printf("⚠️  failed to encode coder artifacts: %v\n", err)
+		return
+	}
+	collab.SharedKnowledge[CoderArtifactsKey] = string(encoded)
+}
+
+// DecodeCoderArtifacts reads back the map[filename]content CoderStrategy
+// stashed into sharedKnowledge[CoderArtifactsKey]. An absent key returns an
+// empty map, not an error.
+func DecodeCoderArtifacts(sharedKnowledge map[string]string) (map[string]string, error) {
+	encoded, ok := sharedKnowledge[CoderArtifactsKey]
+	if !ok {
+		return make(map[string]string), nil
+	}
+	var artifacts map[string]string
+	if err := json.Unmarshal([]byte(encoded), &artifacts); err != nil {
+		return nil, fmt.Errorf("decode coder artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// extractFencedCodeBlocks scans text for "**path:**" header lines
+// immediately followed by a fenced code block (```lang ... ```) and returns
+// the block bodies keyed by path.
+func extractFencedCodeBlocks(text string) map[string]string {
+	artifacts := make(map[string]string)
+	lines := strings.Split(text, "\n")
+
+	var path string
+	var inBlock bool
+	var block strings.Builder
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inBlock:
+			if strings.HasPrefix(trimmed, "```") {
+				if path != "" {
+					artifacts[path] = block.String()
+				}
+				inBlock, path = false, ""
+				block.Reset()
+				continue
+			}
+			block.WriteString(line)
+			block.WriteString("\n")
+		case strings.HasPrefix(trimmed, "**") && strings.HasSuffix(trimmed, ":**"):
+			path = strings.TrimSuffix(strings.TrimPrefix(trimmed, "**"), ":**")
+		case strings.HasPrefix(trimmed, "```"):
+			inBlock = true
+		}
+	}
+
+	return artifacts
+}
@@ -0,0 +1,106 @@
+package breeze
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// fakeMethodBackend is a Backend stub that records every prompt it's given
+// and always returns Reply, so a test can assert a Method-driven phase
+// reached it instead of falling through to the package-level AI call.
+type fakeMethodBackend struct {
+	Reply   string
+	prompts []string
+}
+
+func (b *fakeMethodBackend) Generate(_ context.Context, messages []Message, _ RequestOptions) (string, error) {
+	b.prompts = append(b.prompts, messages[len(messages)-1].Content)
+	return b.Reply, nil
+}
+
+func (b *fakeMethodBackend) GenerateStream(ctx context.Context, messages []Message, opts RequestOptions) (<-chan Token, func() error) {
+	ch := make(chan Token, 1)
+	text, _ := b.Generate(ctx, messages, opts)
+	ch <- Token{Text: text}
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+// TestSequential_RoutesThroughAgentBackendAndPostStep exercises the full
+// pipeline policyAI must wrap (see its doc comment): a Phase.Method must
+// honor Agent.Backend overrides rather than calling AI directly, and the
+// response must still pass through Phase.PostStep before landing in
+// results, exactly like runPhase's sequential path and runParallelPhase do.
+func TestSequential_RoutesThroughAgentBackendAndPostStep(t *testing.T) {
+	backend := &fakeMethodBackend{Reply: "draft answer"}
+	agent := Agent{Name: "Alice", Backend: backend}
+
+	collab := &Collaboration{Agents: []Agent{agent}, SharedKnowledge: make(map[string]string)}
+	phase := Phase{
+		Name:   "Design",
+		Method: Sequential(),
+		PostStep: []PhaseMiddleware{
+			func(_ PhaseContext, output string) (string, error) {
+				return strings.ToUpper(output), nil
+			},
+		},
+	}
+
+	results := phase.Method([]Agent{agent}, collab, phase, "design the widget")
+
+	if len(backend.prompts) != 1 {
+		t.Fatalf("Expected the agent's Backend to be invoked once, got %d calls", len(backend.prompts))
+	}
+	if results["Alice"] != "DRAFT ANSWER" {
+		t.Errorf("Expected Phase.PostStep to run on the Backend's response, got %q", results["Alice"])
+	}
+}
+
+func TestExtractFencedCodeBlocks(t *testing.T) {
+	text := "Here is my work:\n\n**internal/widget/widget.go:**\n```go\npackage widget\n\nfunc New() {}\n```\n\nDone."
+
+	blocks := extractFencedCodeBlocks(text)
+	if len(blocks) != 1 {
+		t.Fatalf("Expected 1 code block, got %d: %+v", len(blocks), blocks)
+	}
+	got := blocks["internal/widget/widget.go"]
+	if got != "package widget\n\nfunc New() {}\n" {
+		t.Errorf("Unexpected block content: %q", got)
+	}
+}
+
+func TestExtractFencedCodeBlocks_IgnoresUnlabeledBlocks(t *testing.T) {
+	text := "```go\nfmt.Println(\"no header\")\n```"
+	if blocks := extractFencedCodeBlocks(text); len(blocks) != 0 {
+		t.Errorf("Expected a fenced block with no **path:** header to be ignored, got %+v", blocks)
+	}
+}
+
+func TestDecodeCoderArtifacts_AbsentKey(t *testing.T) {
+	artifacts, err := DecodeCoderArtifacts(map[string]string{})
+	if err != nil {
+		t.Fatalf("DecodeCoderArtifacts failed: %v", err)
+	}
+	if len(artifacts) != 0 {
+		t.Errorf("Expected no artifacts when the key is absent, got %+v", artifacts)
+	}
+}
+
+func TestMergeCoderArtifacts_RoundTrip(t *testing.T) {
+	collab := &Collaboration{SharedKnowledge: make(map[string]string)}
+
+	mergeCoderArtifacts(collab, map[string]string{"a.go": "package a"})
+	mergeCoderArtifacts(collab, map[string]string{"b.go": "package b", "a.go": "package a // revised"})
+
+	artifacts, err := DecodeCoderArtifacts(collab.SharedKnowledge)
+	if err != nil {
+		t.Fatalf("DecodeCoderArtifacts failed: %v", err)
+	}
+	if artifacts["a.go"] != "package a // revised" {
+		t.Errorf("Expected a later merge to overwrite an earlier file, got %q", artifacts["a.go"])
+	}
+	if artifacts["b.go"] != "package b" {
+		t.Errorf("Expected b.go to be present, got %+v", artifacts)
+	}
+}
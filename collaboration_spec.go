@@ -0,0 +1,291 @@
+package breeze
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CollaborationSpec is the declarative, file-authored description of a
+// TeamCollaboration: which agents exist, how they're grouped into teams,
+// and what phases they run through. LoadCollaborationSpec/
+// LoadCollaborationSpecBytes turn one of these (YAML or JSON - yaml.v3
+// parses both) into a ready-to-Run *TeamCollaboration, so a pipeline can be
+// authored and version-controlled separately from the binary instead of
+// hardcoded like TeamDevCollab/QuickTeamCollab.
+type CollaborationSpec struct {
+	// Agents is an optional shared roster. A TeamSpec.Agents entry that sets
+	// only Name references an agent defined here instead of redefining it,
+	// so the same agent can sit on more than one team.
+	Agents              []AgentSpec       `yaml:"agents,omitempty" json:"agents,omitempty"`
+	Teams               []TeamSpec        `yaml:"teams" json:"teams"`
+	Phases              []PhaseSpec       `yaml:"phases" json:"phases"`
+	SharedKnowledgeSeed map[string]string `yaml:"shared_knowledge_seed,omitempty" json:"shared_knowledge_seed,omitempty"`
+	// OnPhase names a hook registered with RegisterCollaborationHook, wired
+	// up as the loaded TeamCollaboration's OnPhaseComplete.
+	OnPhase string `yaml:"on_phase,omitempty" json:"on_phase,omitempty"`
+}
+
+// TeamSpec describes one Team and its agents.
+type TeamSpec struct {
+	Name        string      `yaml:"name" json:"name"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+	Agents      []AgentSpec `yaml:"agents" json:"agents"`
+}
+
+// AgentSpec describes one Agent. An entry with only Name set is a reference
+// into CollaborationSpec.Agents rather than a full definition.
+type AgentSpec struct {
+	Name        string `yaml:"name" json:"name"`
+	Role        string `yaml:"role,omitempty" json:"role,omitempty"`
+	Expertise   string `yaml:"expertise,omitempty" json:"expertise,omitempty"`
+	Personality string `yaml:"personality,omitempty" json:"personality,omitempty"`
+	// Provider names an entry in modelProviderPrefixes (e.g. "openai",
+	// "anthropic", "google", "ollama"), pinning this agent the same way
+	// Agent.Provider/WithProvider would.
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Model    string `yaml:"model,omitempty" json:"model,omitempty"`
+}
+
+// isReference reports whether spec only names an agent defined elsewhere
+// (in CollaborationSpec.Agents) rather than fully defining one.
+func (spec AgentSpec) isReference() bool {
+	return spec.Role == "" && spec.Expertise == "" && spec.Personality == "" && spec.Provider == "" && spec.Model == ""
+}
+
+// PhaseSpec describes one Phase, including its dependencies on other
+// phases by name.
+type PhaseSpec struct {
+	Name           string   `yaml:"name" json:"name"`
+	Description    string   `yaml:"description,omitempty" json:"description,omitempty"`
+	PromptTemplate string   `yaml:"prompt_template" json:"prompt_template"`
+	IsParallel     bool     `yaml:"is_parallel,omitempty" json:"is_parallel,omitempty"`
+	MaxConcurrency int      `yaml:"max_concurrency,omitempty" json:"max_concurrency,omitempty"`
+	// DependsOn names phases that must appear earlier in the pipeline.
+	// LoadCollaborationSpec topologically sorts Phases so Run (which
+	// executes phases strictly in order) always honors this.
+	DependsOn []string `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+}
+
+// CollaborationHooks maps a name usable in a spec's on_phase field to the
+// Go callback it should invoke. Register a hook before loading any spec
+// that references it.
+var CollaborationHooks = map[string]func(phaseName string, results map[string]string){}
+
+// RegisterCollaborationHook makes fn loadable by name as on_phase in a
+// CollaborationSpec.
+func RegisterCollaborationHook(name string, fn func(phaseName string, results map[string]string)) {
+	CollaborationHooks[name] = fn
+}
+
+// LoadCollaborationSpec reads and parses the collaboration spec at path
+// (YAML or JSON) into a ready-to-Run *TeamCollaboration.
+func LoadCollaborationSpec(path string) (*TeamCollaboration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read collaboration spec %s: %w", path, err)
+	}
+	tc, err := LoadCollaborationSpecBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("load collaboration spec %s: %w", path, err)
+	}
+	return tc, nil
+}
+
+// LoadCollaborationSpecBytes parses data (YAML or JSON) into a
+// ready-to-Run *TeamCollaboration, validating it first (unknown agent
+// references, cyclic or unknown phase dependencies, empty prompts) and
+// topologically sorting Phases by PhaseSpec.DependsOn.
+func LoadCollaborationSpecBytes(data []byte) (*TeamCollaboration, error) {
+	var spec CollaborationSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse collaboration spec: %w", err)
+	}
+
+	if err := validateCollaborationSpec(&spec); err != nil {
+		return nil, err
+	}
+
+	roster := make(map[string]AgentSpec, len(spec.Agents))
+	for _, a := range spec.Agents {
+		roster[a.Name] = a
+	}
+
+	teams := make([]Team, len(spec.Teams))
+	for i, teamSpec := range spec.Teams {
+		agents := make([]Agent, len(teamSpec.Agents))
+		for j, agentSpec := range teamSpec.Agents {
+			if agentSpec.isReference() {
+				agentSpec = roster[agentSpec.Name]
+			}
+			agents[j] = resolveAgentSpec(agentSpec)
+		}
+		teams[i] = Team{Name: teamSpec.Name, Description: teamSpec.Description, Agents: agents}
+	}
+
+	orderedPhaseSpecs, err := topoSortPhases(spec.Phases)
+	if err != nil {
+		return nil, err
+	}
+	phases := make([]Phase, len(orderedPhaseSpecs))
+	for i, phaseSpec := range orderedPhaseSpecs {
+		phases[i] = Phase{
+			Name:           phaseSpec.Name,
+			Description:    phaseSpec.Description,
+			PromptTemplate: phaseSpec.PromptTemplate,
+			IsParallel:     phaseSpec.IsParallel,
+			MaxConcurrency: phaseSpec.MaxConcurrency,
+			DependsOn:      phaseSpec.DependsOn,
+		}
+	}
+
+	tc := NewTeamCollaboration(teams, phases)
+	for k, v := range spec.SharedKnowledgeSeed {
+		tc.SharedKnowledge[k] = v
+	}
+	if spec.OnPhase != "" {
+		hook, ok := CollaborationHooks[spec.OnPhase]
+		if !ok {
+			return nil, fmt.Errorf("collaboration spec: unknown on_phase hook %q (register it with RegisterCollaborationHook first)", spec.OnPhase)
+		}
+		tc.OnPhaseComplete = hook
+	}
+
+	return tc, nil
+}
+
+// resolveAgentSpec converts spec into an Agent, resolving Provider (a
+// string like "openai") the same way a "<provider>:<model>" Model string
+// would via modelProviderPrefixes.
+func resolveAgentSpec(spec AgentSpec) Agent {
+	agent := Agent{
+		Name:        spec.Name,
+		Role:        spec.Role,
+		Expertise:   spec.Expertise,
+		Personality: spec.Personality,
+		Model:       spec.Model,
+	}
+	if spec.Provider != "" {
+		if p, ok := modelProviderPrefixes[spec.Provider]; ok {
+			agent.Provider = p
+		}
+	}
+	return agent
+}
+
+// validateCollaborationSpec checks a freshly-parsed spec for the mistakes a
+// hand-written YAML/JSON file is prone to: a phase with no prompt, a
+// depends_on naming a phase that doesn't exist (or a cycle among phases),
+// and a team agent reference that matches nothing in the shared roster.
+func validateCollaborationSpec(spec *CollaborationSpec) error {
+	roster := make(map[string]bool, len(spec.Agents))
+	for _, a := range spec.Agents {
+		roster[a.Name] = true
+	}
+	for _, team := range spec.Teams {
+		for _, a := range team.Agents {
+			if a.isReference() && !roster[a.Name] {
+				return fmt.Errorf("collaboration spec: team %q references unknown agent %q", team.Name, a.Name)
+			}
+		}
+	}
+
+	phaseNames := make(map[string]bool, len(spec.Phases))
+	for _, phase := range spec.Phases {
+		if phase.Name == "" {
+			return fmt.Errorf("collaboration spec: phase with empty name")
+		}
+		if phase.PromptTemplate == "" {
+			return fmt.Errorf("collaboration spec: phase %q has an empty prompt_template", phase.Name)
+		}
+		phaseNames[phase.Name] = true
+	}
+	for _, phase := range spec.Phases {
+		for _, dep := range phase.DependsOn {
+			if !phaseNames[dep] {
+				return fmt.Errorf("collaboration spec: phase %q depends_on unknown phase %q", phase.Name, dep)
+			}
+		}
+	}
+
+	_, err := topoSortPhases(spec.Phases)
+	return err
+}
+
+// topoSortPhases orders phases so every phase comes after everything it
+// depends_on, via Kahn's algorithm. Ties keep the spec's original relative
+// order. Returns an error naming a cycle if one exists.
+func topoSortPhases(phases []PhaseSpec) ([]PhaseSpec, error) {
+	byName := make(map[string]PhaseSpec, len(phases))
+	inDegree := make(map[string]int, len(phases))
+	for _, phase := range phases {
+		byName[phase.Name] = phase
+		if _, ok := inDegree[phase.Name]; !ok {
+			inDegree[phase.Name] = 0
+		}
+	}
+	dependents := make(map[string][]string)
+	for _, phase := range phases {
+		for _, dep := range phase.DependsOn {
+			dependents[dep] = append(dependents[dep], phase.Name)
+			inDegree[phase.Name]++
+		}
+	}
+
+	var ready []string
+	for _, phase := range phases {
+		if inDegree[phase.Name] == 0 {
+			ready = append(ready, phase.Name)
+		}
+	}
+
+	var ordered []PhaseSpec
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, byName[name])
+		for _, next := range dependents[name] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(ordered) != len(phases) {
+		return nil, fmt.Errorf("collaboration spec: phases have a cyclic dependency")
+	}
+	return ordered, nil
+}
+
+// promptTemplateData is what {{.Project}}/{{.SharedKnowledge.Name}} resolve
+// against inside a PhaseSpec.PromptTemplate.
+type promptTemplateData struct {
+	Project         string
+	SharedKnowledge map[string]string
+}
+
+// renderPromptTemplate executes tmpl as a text/template against data built
+// from initialPrompt and sharedKnowledge, so a spec's prompt_template can
+// reference {{.Project}} and {{.SharedKnowledge.SomeAgent}}. A template
+// with no actions (every hardcoded PromptTemplate before this) round-trips
+// unchanged; a template that fails to parse/execute is returned as-is so a
+// typo degrades to a literal prompt instead of losing the phase entirely.
+func renderPromptTemplate(tmpl string, initialPrompt string, sharedKnowledge map[string]string) string {
+	if !strings.Contains(tmpl, "{{") {
+		return tmpl
+	}
+	t, err := template.New("phase-prompt").Parse(tmpl)
+	if err != nil {
+		return tmpl
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, promptTemplateData{Project: initialPrompt, SharedKnowledge: sharedKnowledge}); err != nil {
+		return tmpl
+	}
+	return buf.String()
+}
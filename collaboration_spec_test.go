@@ -0,0 +1,159 @@
+package breeze
+
+import "testing"
+
+func TestTopoSortPhases_OrdersByDependency(t *testing.T) {
+	phases := []PhaseSpec{
+		{Name: "Final Polish", PromptTemplate: "polish", DependsOn: []string{"Testing"}},
+		{Name: "Requirements", PromptTemplate: "requirements"},
+		{Name: "Testing", PromptTemplate: "test", DependsOn: []string{"Implementation"}},
+		{Name: "Implementation", PromptTemplate: "implement", DependsOn: []string{"Requirements"}},
+	}
+
+	ordered, err := topoSortPhases(phases)
+	if err != nil {
+		t.Fatalf("topoSortPhases failed: %v", err)
+	}
+
+	pos := make(map[string]int, len(ordered))
+	for i, phase := range ordered {
+		pos[phase.Name] = i
+	}
+	if pos["Requirements"] > pos["Implementation"] || pos["Implementation"] > pos["Testing"] || pos["Testing"] > pos["Final Polish"] {
+		t.Errorf("Expected Requirements < Implementation < Testing < Final Polish, got order %+v", ordered)
+	}
+}
+
+func TestTopoSortPhases_DetectsCycle(t *testing.T) {
+	phases := []PhaseSpec{
+		{Name: "A", PromptTemplate: "a", DependsOn: []string{"B"}},
+		{Name: "B", PromptTemplate: "b", DependsOn: []string{"A"}},
+	}
+	if _, err := topoSortPhases(phases); err == nil {
+		t.Error("Expected topoSortPhases to reject a cyclic dependency")
+	}
+}
+
+func TestValidateCollaborationSpec_EmptyPrompt(t *testing.T) {
+	spec := &CollaborationSpec{Phases: []PhaseSpec{{Name: "Requirements"}}}
+	if err := validateCollaborationSpec(spec); err == nil {
+		t.Error("Expected validateCollaborationSpec to reject an empty prompt_template")
+	}
+}
+
+func TestValidateCollaborationSpec_UnknownAgentReference(t *testing.T) {
+	spec := &CollaborationSpec{
+		Teams: []TeamSpec{{
+			Name:   "SW",
+			Agents: []AgentSpec{{Name: "Ghost"}},
+		}},
+		Phases: []PhaseSpec{{Name: "Requirements", PromptTemplate: "go"}},
+	}
+	if err := validateCollaborationSpec(spec); err == nil {
+		t.Error("Expected validateCollaborationSpec to reject an unresolved agent reference")
+	}
+}
+
+func TestValidateCollaborationSpec_UnknownDependsOn(t *testing.T) {
+	spec := &CollaborationSpec{
+		Phases: []PhaseSpec{{Name: "Requirements", PromptTemplate: "go", DependsOn: []string{"NoSuchPhase"}}},
+	}
+	if err := validateCollaborationSpec(spec); err == nil {
+		t.Error("Expected validateCollaborationSpec to reject a depends_on naming an unknown phase")
+	}
+}
+
+func TestLoadCollaborationSpecBytes(t *testing.T) {
+	spec := []byte(`
+agents:
+  - name: Alice
+    role: Senior Engineer
+    expertise: Go
+teams:
+  - name: SW
+    agents:
+      - name: Alice
+  - name: QA
+    agents:
+      - name: Alice
+phases:
+  - name: Testing
+    prompt_template: "test it"
+    depends_on: ["Requirements"]
+  - name: Requirements
+    prompt_template: "gather requirements"
+`)
+
+	tc, err := LoadCollaborationSpecBytes(spec)
+	if err != nil {
+		t.Fatalf("LoadCollaborationSpecBytes failed: %v", err)
+	}
+
+	if len(tc.Teams) != 2 || tc.Teams[0].Agents[0].Role != "Senior Engineer" {
+		t.Fatalf("Expected both teams to resolve the Alice reference, got %+v", tc.Teams)
+	}
+	if tc.Phases[0].Name != "Requirements" || tc.Phases[1].Name != "Testing" {
+		t.Errorf("Expected phases reordered to [Requirements, Testing], got [%s, %s]", tc.Phases[0].Name, tc.Phases[1].Name)
+	}
+}
+
+// TestLoadCollaborationSpecBytes_PreservesDependsOnForConcurrency checks
+// that a loaded spec's phases keep their depends_on, so two phases with no
+// dependency between them land in the same phaseLayers layer (and so run
+// concurrently under TeamCollaboration.Run) instead of being forced linear
+// by resolvePhaseDependencies' "empty DependsOn means previous phase"
+// default.
+func TestLoadCollaborationSpecBytes_PreservesDependsOnForConcurrency(t *testing.T) {
+	spec := []byte(`
+teams:
+  - name: SW
+    agents:
+      - name: Alice
+        role: Senior Engineer
+phases:
+  - name: Requirements
+    prompt_template: "gather requirements"
+  - name: Frontend
+    prompt_template: "build the UI"
+    depends_on: ["Requirements"]
+  - name: Backend
+    prompt_template: "build the API"
+    depends_on: ["Requirements"]
+`)
+
+	tc, err := LoadCollaborationSpecBytes(spec)
+	if err != nil {
+		t.Fatalf("LoadCollaborationSpecBytes failed: %v", err)
+	}
+
+	layers, err := phaseLayers(tc.Phases)
+	if err != nil {
+		t.Fatalf("phaseLayers failed: %v", err)
+	}
+	for _, layer := range layers {
+		if len(layer) == 2 {
+			names := map[string]bool{}
+			for _, p := range layer {
+				names[p.Name] = true
+			}
+			if names["Frontend"] && names["Backend"] {
+				return
+			}
+		}
+	}
+	t.Fatalf("Expected Frontend and Backend to share a layer (both depend only on Requirements), got layers %+v", layers)
+}
+
+func TestRenderPromptTemplate(t *testing.T) {
+	rendered := renderPromptTemplate("Build {{.Project}}. SW said: {{.SharedKnowledge.SW}}", "a web scraper", map[string]string{"SW": "use Go"})
+	if rendered != "Build a web scraper. SW said: use Go" {
+		t.Errorf("Unexpected render: %q", rendered)
+	}
+}
+
+func TestRenderPromptTemplate_NoTemplateActionsPassesThrough(t *testing.T) {
+	plain := "Provide your expert contribution. Be specific and actionable."
+	if got := renderPromptTemplate(plain, "project", nil); got != plain {
+		t.Errorf("Expected plain template to pass through unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,412 @@
+package breeze
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EvalContext is the variable environment Phase.Condition, Agent.When, and
+// Collaboration.Router expressions are evaluated against: {phase, agents,
+// results, shared_knowledge, iteration}.
+type EvalContext struct {
+	Phase           Phase
+	Agents          []Agent
+	Results         map[string]map[string]string
+	SharedKnowledge map[string]string
+	Iteration       int
+}
+
+// ExpressionError reports which expression (a Phase.Condition, an
+// Agent.When, or Collaboration.Router) failed to compile or evaluate, and
+// why, so NewCollaboration/NewTeamCollaboration callers get a typed error
+// up front instead of a mid-run failure.
+type ExpressionError struct {
+	Field string // e.g. "Phase(Lyrics Creation).Condition"
+	Expr  string
+	Err   error
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("%s: invalid expression %q: %v", e.Field, e.Expr, e.Err)
+}
+
+func (e *ExpressionError) Unwrap() error { return e.Err }
+
+// compiledExpr is an expression parsed once so repeated evaluation (e.g. a
+// Router checked every loop iteration) doesn't re-parse it each time.
+type compiledExpr struct {
+	src  string
+	node ast.Expr
+}
+
+// compileExpr parses a small CEL-like expression language: ==, !=, <, <=, >,
+// >=, &&, ||, !, dotted field access (phase.name), ['key'] indexing
+// (results['Phase1']['Alice']), and the builtins contains(s, sub) and
+// len(x). It's implemented on top of Go's own expression grammar (which
+// covers this exact surface) rather than a bespoke parser, after rewriting
+// 'single-quoted' string literals to Go's "double-quoted" form.
+func compileExpr(src string) (*compiledExpr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, nil
+	}
+	node, err := parser.ParseExpr(singleToDoubleQuotedStrings(src))
+	if err != nil {
+		return nil, err
+	}
+	return &compiledExpr{src: src, node: node}, nil
+}
+
+// singleToDoubleQuotedStrings rewrites 'foo' string literals, the
+// conventional quoting for CEL and most scripting languages, to Go's "foo"
+// (Go's parser treats a single-quoted 'f' as a rune literal instead).
+func singleToDoubleQuotedStrings(src string) string {
+	var b strings.Builder
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\'' {
+			b.WriteByte('"')
+			continue
+		}
+		b.WriteByte(src[i])
+	}
+	return b.String()
+}
+
+// eval evaluates the compiled expression against ctx.
+func (c *compiledExpr) eval(ctx EvalContext) (interface{}, error) {
+	return evalNode(c.node, ctx)
+}
+
+// evalBool evaluates the compiled expression and requires a bool result, as
+// Phase.Condition and Agent.When do. A nil expression (Condition/When unset)
+// is always true, preserving the static, always-run behavior.
+func (c *compiledExpr) evalBool(ctx EvalContext) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+	v, err := c.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q did not evaluate to a bool (got %T)", c.src, v)
+	}
+	return b, nil
+}
+
+// evalString evaluates the compiled expression and requires a string
+// result, as Collaboration.Router (next phase name) does.
+func (c *compiledExpr) evalString(ctx EvalContext) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	v, err := c.eval(ctx)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expression %q did not evaluate to a string (got %T)", c.src, v)
+	}
+	return s, nil
+}
+
+func evalNode(n ast.Expr, ctx EvalContext) (interface{}, error) {
+	switch node := n.(type) {
+	case *ast.ParenExpr:
+		return evalNode(node.X, ctx)
+
+	case *ast.BasicLit:
+		switch node.Kind {
+		case token.STRING:
+			return strconv.Unquote(node.Value)
+		case token.INT:
+			return strconv.Atoi(node.Value)
+		case token.FLOAT:
+			return strconv.ParseFloat(node.Value, 64)
+		default:
+			return nil, fmt.Errorf("unsupported literal %q", node.Value)
+		}
+
+	case *ast.Ident:
+		return evalIdent(node.Name, ctx)
+
+	case *ast.SelectorExpr:
+		base, err := evalNode(node.X, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return evalSelector(base, node.Sel.Name)
+
+	case *ast.IndexExpr:
+		base, err := evalNode(node.X, ctx)
+		if err != nil {
+			return nil, err
+		}
+		key, err := evalNode(node.Index, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return evalIndex(base, key)
+
+	case *ast.UnaryExpr:
+		x, err := evalNode(node.X, ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch node.Op {
+		case token.NOT:
+			b, ok := x.(bool)
+			if !ok {
+				return nil, fmt.Errorf("! requires a bool operand, got %T", x)
+			}
+			return !b, nil
+		case token.SUB:
+			return negate(x)
+		default:
+			return nil, fmt.Errorf("unsupported unary operator %s", node.Op)
+		}
+
+	case *ast.BinaryExpr:
+		return evalBinary(node, ctx)
+
+	case *ast.CallExpr:
+		return evalCall(node, ctx)
+
+	default:
+		return nil, fmt.Errorf("unsupported expression syntax: %T", n)
+	}
+}
+
+func evalIdent(name string, ctx EvalContext) (interface{}, error) {
+	switch name {
+	case "phase":
+		return ctx.Phase, nil
+	case "agents":
+		return ctx.Agents, nil
+	case "results":
+		return ctx.Results, nil
+	case "shared_knowledge":
+		return ctx.SharedKnowledge, nil
+	case "iteration":
+		return ctx.Iteration, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return nil, fmt.Errorf("undefined variable %q", name)
+	}
+}
+
+func evalSelector(base interface{}, field string) (interface{}, error) {
+	switch v := base.(type) {
+	case Phase:
+		switch field {
+		case "name":
+			return v.Name, nil
+		case "description":
+			return v.Description, nil
+		case "condition":
+			return v.Condition, nil
+		}
+	case Agent:
+		switch field {
+		case "name":
+			return v.Name, nil
+		case "role":
+			return v.Role, nil
+		case "expertise":
+			return v.Expertise, nil
+		case "when":
+			return v.When, nil
+		case "seniority":
+			return v.Seniority, nil
+		}
+	}
+	return nil, fmt.Errorf("no field %q on %T", field, base)
+}
+
+func evalIndex(base, key interface{}) (interface{}, error) {
+	switch v := base.(type) {
+	case map[string]string:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, got %T", key)
+		}
+		return v[k], nil
+	case map[string]map[string]string:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, got %T", key)
+		}
+		return v[k], nil
+	default:
+		return nil, fmt.Errorf("cannot index %T", base)
+	}
+}
+
+func negate(x interface{}) (interface{}, error) {
+	switch v := x.(type) {
+	case int:
+		return -v, nil
+	case float64:
+		return -v, nil
+	default:
+		return nil, fmt.Errorf("unary - requires a number, got %T", x)
+	}
+}
+
+func evalBinary(node *ast.BinaryExpr, ctx EvalContext) (interface{}, error) {
+	// && and || short-circuit, so evaluate the right side lazily.
+	if node.Op == token.LAND || node.Op == token.LOR {
+		left, err := evalNode(node.X, ctx)
+		if err != nil {
+			return nil, err
+		}
+		lb, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s requires bool operands, got %T", node.Op, left)
+		}
+		if node.Op == token.LAND && !lb {
+			return false, nil
+		}
+		if node.Op == token.LOR && lb {
+			return true, nil
+		}
+		right, err := evalNode(node.Y, ctx)
+		if err != nil {
+			return nil, err
+		}
+		rb, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s requires bool operands, got %T", node.Op, right)
+		}
+		return rb, nil
+	}
+
+	left, err := evalNode(node.X, ctx)
+	if err != nil {
+		return nil, err
+	}
+	right, err := evalNode(node.Y, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Op {
+	case token.EQL:
+		return reflect.DeepEqual(left, right), nil
+	case token.NEQ:
+		return !reflect.DeepEqual(left, right), nil
+	case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return compareOrdered(node.Op, left, right)
+	default:
+		return nil, fmt.Errorf("unsupported binary operator %s", node.Op)
+	}
+}
+
+func compareOrdered(op token.Token, left, right interface{}) (interface{}, error) {
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if lok && rok {
+		return compareFloats(op, lf, rf), nil
+	}
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		return compareStrings(op, ls, rs), nil
+	}
+	return nil, fmt.Errorf("cannot compare %T and %T with %s", left, right, op)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func compareFloats(op token.Token, l, r float64) bool {
+	switch op {
+	case token.LSS:
+		return l < r
+	case token.LEQ:
+		return l <= r
+	case token.GTR:
+		return l > r
+	case token.GEQ:
+		return l >= r
+	}
+	return false
+}
+
+func compareStrings(op token.Token, l, r string) bool {
+	switch op {
+	case token.LSS:
+		return l < r
+	case token.LEQ:
+		return l <= r
+	case token.GTR:
+		return l > r
+	case token.GEQ:
+		return l >= r
+	}
+	return false
+}
+
+func evalCall(node *ast.CallExpr, ctx EvalContext) (interface{}, error) {
+	fnIdent, ok := node.Fun.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported call target %T", node.Fun)
+	}
+
+	args := make([]interface{}, len(node.Args))
+	for i, a := range node.Args {
+		v, err := evalNode(a, ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	switch fnIdent.Name {
+	case "contains":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("contains() takes 2 arguments, got %d", len(args))
+		}
+		haystack, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("contains(): first argument must be a string, got %T", args[0])
+		}
+		needle, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("contains(): second argument must be a string, got %T", args[1])
+		}
+		return strings.Contains(haystack, needle), nil
+
+	case "len":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("len() takes 1 argument, got %d", len(args))
+		}
+		v := reflect.ValueOf(args[0])
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			return v.Len(), nil
+		default:
+			return nil, fmt.Errorf("len(): cannot take the length of %T", args[0])
+		}
+
+	default:
+		return nil, fmt.Errorf("undefined function %q", fnIdent.Name)
+	}
+}
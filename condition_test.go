@@ -0,0 +1,96 @@
+package breeze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompileExpr_EmptyIsAlwaysTrue(t *testing.T) {
+	compiled, err := compileExpr("")
+	if err != nil {
+		t.Fatalf("compileExpr failed: %v", err)
+	}
+	ok, err := compiled.evalBool(EvalContext{})
+	if err != nil {
+		t.Fatalf("evalBool failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected an empty Condition/When to always evaluate true")
+	}
+}
+
+func TestCompileExpr_PhaseNameEquality(t *testing.T) {
+	compiled, err := compileExpr("phase.name == 'Technical Design'")
+	if err != nil {
+		t.Fatalf("compileExpr failed: %v", err)
+	}
+
+	ok, err := compiled.evalBool(EvalContext{Phase: Phase{Name: "Technical Design"}})
+	if err != nil {
+		t.Fatalf("evalBool failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected phase.name == 'Technical Design' to be true")
+	}
+
+	ok, err = compiled.evalBool(EvalContext{Phase: Phase{Name: "Lyrics Creation"}})
+	if err != nil {
+		t.Fatalf("evalBool failed: %v", err)
+	}
+	if ok {
+		t.Error("expected phase.name == 'Technical Design' to be false for a different phase")
+	}
+}
+
+func TestCompileExpr_ContainsAndLen(t *testing.T) {
+	ctx := EvalContext{
+		Results:         map[string]map[string]string{"Phase1": {"Alice": "still has a TODO"}},
+		SharedKnowledge: map[string]string{"Alice": "done", "Bob": "done"},
+	}
+
+	compiled, err := compileExpr("contains(results['Phase1']['Alice'], 'TODO')")
+	if err != nil {
+		t.Fatalf("compileExpr failed: %v", err)
+	}
+	ok, err := compiled.evalBool(ctx)
+	if err != nil {
+		t.Fatalf("evalBool failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected contains() to find TODO in the nested result")
+	}
+
+	lenCompiled, err := compileExpr("len(shared_knowledge) == 2")
+	if err != nil {
+		t.Fatalf("compileExpr failed: %v", err)
+	}
+	ok, err = lenCompiled.evalBool(ctx)
+	if err != nil {
+		t.Fatalf("evalBool failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected len(shared_knowledge) == 2 to be true")
+	}
+}
+
+func TestCompileExpr_InvalidSyntaxError(t *testing.T) {
+	if _, err := compileExpr("phase.name =="); err == nil {
+		t.Error("expected an error compiling malformed syntax")
+	}
+}
+
+func TestCollaborationValidateExpressions(t *testing.T) {
+	collab := NewCollaboration(
+		[]Agent{{Name: "Alice", When: "phase.name =="}},
+		[]Phase{{Name: "Draft"}},
+	)
+
+	err := collab.ValidateExpressions()
+	if err == nil {
+		t.Fatal("expected ValidateExpressions to reject Alice's malformed When")
+	}
+	var exprErr *ExpressionError
+	if !errors.As(err, &exprErr) {
+		t.Fatalf("expected an *ExpressionError, got %T", err)
+	}
+}
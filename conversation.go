@@ -0,0 +1,521 @@
+package breeze
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConversationMessage is one persisted turn of a Conversation: who said it,
+// in what phase/agent/model context, and which message it replied to, so a
+// conversation's history can be replayed as a tree rather than just a list.
+type ConversationMessage struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	Role      string    `json:"role"`
+	Agent     string    `json:"agent,omitempty"`
+	Phase     string    `json:"phase,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	Content   string    `json:"content"`
+	Tokens    int       `json:"tokens,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ConversationStore persists ConversationMessages so a Conversation can be
+// resumed after a crash, branched from any prior turn, or listed later.
+type ConversationStore interface {
+	Append(conversationID string, msg ConversationMessage) error
+	Messages(conversationID string) ([]ConversationMessage, error)
+	List() ([]string, error)
+	// Truncate drops every message after (and not including) afterMessageID,
+	// so EditAndResend can rewrite history before regenerating it. An empty
+	// afterMessageID truncates to nothing.
+	Truncate(conversationID, afterMessageID string) error
+}
+
+// defaultConversationStore is used by NewConversation/LoadConversation when
+// no store is set explicitly. It persists to ~/.breeze/conversations/ (or
+// ./.breeze if the home directory can't be resolved).
+var defaultConversationStore ConversationStore = NewFileConversationStore(defaultConversationDir())
+
+// SetConversationStore installs s as the package-level default
+// ConversationStore.
+func SetConversationStore(s ConversationStore) {
+	defaultConversationStore = s
+}
+
+func defaultConversationDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".breeze/conversations"
+	}
+	return filepath.Join(home, ".breeze", "conversations")
+}
+
+// FileConversationStore persists each conversation as a JSON-lines file,
+// one ConversationMessage per line, under Dir. This keeps the store
+// dependency-free (no SQLite/BoltDB driver needed) while meeting the same
+// append/resume/list contract a DB-backed store would.
+type FileConversationStore struct {
+	Dir string
+}
+
+// NewFileConversationStore creates a store that persists conversations under
+// dir, creating it if necessary.
+func NewFileConversationStore(dir string) *FileConversationStore {
+	return &FileConversationStore{Dir: dir}
+}
+
+func (f *FileConversationStore) path(conversationID string) string {
+	return filepath.Join(f.Dir, conversationID+".jsonl")
+}
+
+// Append writes msg to conversationID's log, creating the conversation (and
+// the store directory) if this is its first message.
+func (f *FileConversationStore) Append(conversationID string, msg ConversationMessage) error {
+	if err := os.MkdirAll(f.Dir, 0755); err != nil {
+		return fmt.Errorf("create conversation store dir: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path(conversationID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open conversation %s: %w", conversationID, err)
+	}
+	defer file.Close()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal conversation message: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("append to conversation %s: %w", conversationID, err)
+	}
+	return nil
+}
+
+// Messages returns every message appended to conversationID, in append
+// order, including across process restarts. Returns an empty slice (not an
+// error) for a conversation that has never been written.
+func (f *FileConversationStore) Messages(conversationID string) ([]ConversationMessage, error) {
+	file, err := os.Open(f.path(conversationID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open conversation %s: %w", conversationID, err)
+	}
+	defer file.Close()
+
+	var messages []ConversationMessage
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var msg ConversationMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return nil, fmt.Errorf("parse conversation %s: %w", conversationID, err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, scanner.Err()
+}
+
+// Truncate rewrites conversationID's log to keep only messages up to and
+// including afterMessageID, discarding the rest.
+func (f *FileConversationStore) Truncate(conversationID, afterMessageID string) error {
+	messages, err := f.Messages(conversationID)
+	if err != nil {
+		return err
+	}
+
+	var kept []ConversationMessage
+	if afterMessageID != "" {
+		for i, msg := range messages {
+			if msg.ID == afterMessageID {
+				kept = messages[:i+1]
+				break
+			}
+		}
+	}
+
+	file, err := os.Create(f.path(conversationID))
+	if err != nil {
+		return fmt.Errorf("truncate conversation %s: %w", conversationID, err)
+	}
+	defer file.Close()
+
+	for _, msg := range kept {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal conversation message: %w", err)
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("rewrite conversation %s: %w", conversationID, err)
+		}
+	}
+	return nil
+}
+
+// List returns the IDs of every conversation with at least one persisted
+// message.
+func (f *FileConversationStore) List() ([]string, error) {
+	entries, err := os.ReadDir(f.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read conversation store dir: %w", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".jsonl") {
+			ids = append(ids, strings.TrimSuffix(name, ".jsonl"))
+		}
+	}
+	return ids, nil
+}
+
+// InMemoryConversationStore keeps conversations in a process-local map. It
+// satisfies the same ConversationStore contract as FileConversationStore
+// (and a future SQLite-backed one) without touching disk, which is handy
+// for tests and for short-lived conversations (e.g. one per HTTP request)
+// that don't need to survive a restart.
+type InMemoryConversationStore struct {
+	mu            sync.Mutex
+	conversations map[string][]ConversationMessage
+}
+
+// NewInMemoryConversationStore creates an empty InMemoryConversationStore.
+func NewInMemoryConversationStore() *InMemoryConversationStore {
+	return &InMemoryConversationStore{conversations: make(map[string][]ConversationMessage)}
+}
+
+// Append records msg under conversationID.
+func (s *InMemoryConversationStore) Append(conversationID string, msg ConversationMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conversations[conversationID] = append(s.conversations[conversationID], msg)
+	return nil
+}
+
+// Messages returns every message appended to conversationID, in append
+// order. Returns an empty slice (not an error) for an unknown conversation.
+func (s *InMemoryConversationStore) Messages(conversationID string) ([]ConversationMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := make([]ConversationMessage, len(s.conversations[conversationID]))
+	copy(messages, s.conversations[conversationID])
+	return messages, nil
+}
+
+// Truncate drops every message after (and not including) afterMessageID.
+func (s *InMemoryConversationStore) Truncate(conversationID, afterMessageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []ConversationMessage
+	if afterMessageID != "" {
+		for i, msg := range s.conversations[conversationID] {
+			if msg.ID == afterMessageID {
+				kept = append([]ConversationMessage(nil), s.conversations[conversationID][:i+1]...)
+				break
+			}
+		}
+	}
+	s.conversations[conversationID] = kept
+	return nil
+}
+
+// List returns the IDs of every conversation with at least one message.
+func (s *InMemoryConversationStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.conversations))
+	for id, messages := range s.conversations {
+		if len(messages) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Conversation is a persistent, branchable chat session. Every turn is
+// recorded to a ConversationStore by ID, so a session survives a crash
+// (LoadConversation with the same ID resumes it) and can be forked from any
+// prior message to try an alternate continuation without losing the
+// original branch.
+type Conversation struct {
+	ID    string
+	store ConversationStore
+}
+
+// defaultConversationID names the Conversation that Chat's implicit global
+// history is recorded to, so Chat stays addressable, forkable, and
+// resumable through the same API as any other Conversation instead of
+// vanishing with the process.
+const defaultConversationID = "chat-default"
+
+// defaultConversation is the Conversation Chat sends to and records into.
+var defaultConversation = LoadConversation(defaultConversationID)
+
+// record appends a plain role/content turn to c, parented to c's last
+// message. Unlike Send, it doesn't generate a reply itself; Chat uses it to
+// mirror turns produced by its own (tool- and image-aware) generation path
+// into the persisted store.
+func (c *Conversation) record(role, content string) error {
+	parentID, err := c.lastMessageID()
+	if err != nil {
+		return err
+	}
+	return c.store.Append(c.ID, ConversationMessage{
+		ID:        c.nextMessageID(),
+		ParentID:  parentID,
+		Role:      role,
+		Content:   content,
+		Timestamp: time.Now(),
+	})
+}
+
+// NewConversation starts a fresh conversation with a generated ID in the
+// package-level default ConversationStore.
+func NewConversation() *Conversation {
+	return &Conversation{ID: fmt.Sprintf("conv-%d", time.Now().UnixNano()), store: defaultConversationStore}
+}
+
+// LoadConversation resumes the conversation with the given ID from the
+// package-level default ConversationStore. The ID need not already exist;
+// an unknown ID behaves like a fresh conversation that happens to have a
+// caller-chosen name.
+func LoadConversation(id string) *Conversation {
+	return &Conversation{ID: id, store: defaultConversationStore}
+}
+
+// Reply appends prompt as a user message, generates a response via AI using
+// opts, appends the response as an assistant message parented to the user
+// message, and returns it.
+func (c *Conversation) Reply(prompt string, opts ...Option) (string, error) {
+	parentID, err := c.lastMessageID()
+	if err != nil {
+		return "", err
+	}
+
+	userMsg := ConversationMessage{
+		ID:        c.nextMessageID(),
+		ParentID:  parentID,
+		Role:      "user",
+		Content:   prompt,
+		Timestamp: time.Now(),
+	}
+	if err := c.store.Append(c.ID, userMsg); err != nil {
+		return "", err
+	}
+
+	response := AI(prompt, opts...)
+
+	options := RequestOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	assistantMsg := ConversationMessage{
+		ID:        c.nextMessageID(),
+		ParentID:  userMsg.ID,
+		Role:      "assistant",
+		Model:     options.Model,
+		Content:   response,
+		Timestamp: time.Now(),
+	}
+	if err := c.store.Append(c.ID, assistantMsg); err != nil {
+		return "", err
+	}
+
+	return response, nil
+}
+
+// Send appends prompt as a user message, generates a reply via a real
+// multi-turn chat call over c's full history (unlike Reply, which only
+// sends the latest prompt through AI), appends the reply as an assistant
+// message parented to the user message, and returns it. This backs Chat
+// once a Conversation is in play, so conversations opened with
+// NewConversation/LoadConversation behave exactly like Chat's old implicit
+// global history, just addressable and persisted by ID.
+func (c *Conversation) Send(prompt string, opts ...Option) (string, error) {
+	history, err := c.store.Messages(c.ID)
+	if err != nil {
+		return "", err
+	}
+	parentID, err := c.lastMessageID()
+	if err != nil {
+		return "", err
+	}
+
+	options := RequestOptions{Model: defaultClient.model, Temp: 0.7}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	applyModelProviderPrefix(&options)
+
+	messages := make([]Message, 0, len(history)+1)
+	for _, msg := range history {
+		messages = append(messages, Message{Role: msg.Role, Content: msg.Content})
+	}
+	messages = append(messages, Message{Role: "user", Content: prompt})
+
+	userMsg := ConversationMessage{
+		ID:        c.nextMessageID(),
+		ParentID:  parentID,
+		Role:      "user",
+		Content:   prompt,
+		Timestamp: time.Now(),
+	}
+	if err := c.store.Append(c.ID, userMsg); err != nil {
+		return "", err
+	}
+
+	response, _ := chatCompletion(messages, options)
+
+	assistantMsg := ConversationMessage{
+		ID:        c.nextMessageID(),
+		ParentID:  userMsg.ID,
+		Role:      "assistant",
+		Model:     options.Model,
+		Content:   response,
+		Timestamp: time.Now(),
+	}
+	if err := c.store.Append(c.ID, assistantMsg); err != nil {
+		return "", err
+	}
+
+	return response, nil
+}
+
+// EditAndResend rewrites the user message identified by messageID to
+// newContent, discards every message that followed it (including whatever
+// reply it originally got), and resends it via Send, returning the newly
+// generated reply. This is how a chat UI built on Conversation supports
+// "edit this message" without losing the rest of the conversation's
+// earlier turns.
+func (c *Conversation) EditAndResend(messageID, newContent string, opts ...Option) (string, error) {
+	history, err := c.store.Messages(c.ID)
+	if err != nil {
+		return "", err
+	}
+
+	var target *ConversationMessage
+	for i := range history {
+		if history[i].ID == messageID {
+			target = &history[i]
+			break
+		}
+	}
+	if target == nil {
+		return "", fmt.Errorf("conversation %s: no message with id %s", c.ID, messageID)
+	}
+	if target.Role != "user" {
+		return "", fmt.Errorf("conversation %s: message %s is not a user message, can't be edited and resent", c.ID, messageID)
+	}
+
+	if err := c.store.Truncate(c.ID, target.ParentID); err != nil {
+		return "", err
+	}
+	return c.Send(newContent, opts...)
+}
+
+// Fork creates a new Conversation that starts as a copy of c's history up to
+// and including messageID, letting callers try an alternate continuation
+// (e.g. a different model for "Final Polish") without altering c. Forking
+// the same messageID more than once produces independent conversations each
+// time - see nextForkID.
+func (c *Conversation) Fork(messageID string) (*Conversation, error) {
+	messages, err := c.store.Messages(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	forkID, err := c.nextForkID(messageID)
+	if err != nil {
+		return nil, err
+	}
+	fork := &Conversation{ID: forkID, store: c.store}
+
+	found := false
+	for _, msg := range messages {
+		if err := fork.store.Append(forkID, msg); err != nil {
+			return nil, err
+		}
+		if msg.ID == messageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("conversation %s: no message with id %s", c.ID, messageID)
+	}
+
+	return fork, nil
+}
+
+// nextForkID returns an unused conversation ID for a fork of messageID,
+// starting at "<id>-fork-<messageID>" and, if that ID already has persisted
+// history (i.e. this message has been forked before), trying "-2", "-3" and
+// so on until it finds a free one. Without this, forking the same message
+// twice - trying two different models off the same turn, say - would make
+// the second Fork append its branch on top of the first fork's store entry
+// instead of starting its own.
+func (c *Conversation) nextForkID(messageID string) (string, error) {
+	base := fmt.Sprintf("%s-fork-%s", c.ID, messageID)
+	candidate := base
+	for n := 2; ; n++ {
+		existing, err := c.store.Messages(candidate)
+		if err != nil {
+			return "", err
+		}
+		if len(existing) == 0 {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// View returns every message in c, in append order.
+func (c *Conversation) View() ([]ConversationMessage, error) {
+	return c.store.Messages(c.ID)
+}
+
+// ListConversations returns the IDs of every conversation in the
+// package-level default ConversationStore.
+func ListConversations() ([]string, error) {
+	return defaultConversationStore.List()
+}
+
+func (c *Conversation) lastMessageID() (string, error) {
+	messages, err := c.store.Messages(c.ID)
+	if err != nil {
+		return "", err
+	}
+	if len(messages) == 0 {
+		return "", nil
+	}
+	return messages[len(messages)-1].ID, nil
+}
+
+// nextMessageID returns a sequential ID ("m1", "m2", ...) scoped to this
+// conversation, so forks can be named after the message they branched from.
+func (c *Conversation) nextMessageID() string {
+	messages, err := c.store.Messages(c.ID)
+	if err != nil {
+		return "m1"
+	}
+	return "m" + strconv.Itoa(len(messages)+1)
+}
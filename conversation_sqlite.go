@@ -0,0 +1,140 @@
+package breeze
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteConversationStore persists ConversationMessages to a SQLite
+// database instead of FileConversationStore's JSONL-per-conversation
+// layout. Reach for it when conversations need to be queried or joined
+// against other application tables with SQL (e.g. a chat UI's backend);
+// otherwise FileConversationStore's dependency-free storage is enough.
+type SQLiteConversationStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteConversationStore opens (creating if necessary) a SQLite
+// database at path and ensures its schema exists.
+func NewSQLiteConversationStore(path string) (*SQLiteConversationStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite conversation store %s: %w", path, err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS conversation_messages (
+	conversation_id TEXT NOT NULL,
+	seq             INTEGER NOT NULL,
+	id              TEXT NOT NULL,
+	parent_id       TEXT,
+	role            TEXT NOT NULL,
+	agent           TEXT,
+	phase           TEXT,
+	provider        TEXT,
+	model           TEXT,
+	content         TEXT NOT NULL,
+	tokens          INTEGER,
+	timestamp       DATETIME NOT NULL,
+	PRIMARY KEY (conversation_id, seq)
+)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create sqlite conversation schema: %w", err)
+	}
+	return &SQLiteConversationStore{db: db}, nil
+}
+
+// Append inserts msg as the next message in conversationID.
+func (s *SQLiteConversationStore) Append(conversationID string, msg ConversationMessage) error {
+	var seq int
+	row := s.db.QueryRow(`SELECT COALESCE(MAX(seq), -1) + 1 FROM conversation_messages WHERE conversation_id = ?`, conversationID)
+	if err := row.Scan(&seq); err != nil {
+		return fmt.Errorf("next seq for conversation %s: %w", conversationID, err)
+	}
+
+	_, err := s.db.Exec(
+		`INSERT INTO conversation_messages
+			(conversation_id, seq, id, parent_id, role, agent, phase, provider, model, content, tokens, timestamp)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		conversationID, seq, msg.ID, msg.ParentID, msg.Role, msg.Agent, msg.Phase, msg.Provider, msg.Model, msg.Content, msg.Tokens, msg.Timestamp,
+	)
+	if err != nil {
+		return fmt.Errorf("append to conversation %s: %w", conversationID, err)
+	}
+	return nil
+}
+
+// Messages returns every message appended to conversationID, in append
+// order. Returns an empty slice (not an error) for a conversation that has
+// never been written.
+func (s *SQLiteConversationStore) Messages(conversationID string) ([]ConversationMessage, error) {
+	rows, err := s.db.Query(
+		`SELECT id, parent_id, role, agent, phase, provider, model, content, tokens, timestamp
+		 FROM conversation_messages WHERE conversation_id = ? ORDER BY seq`,
+		conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query conversation %s: %w", conversationID, err)
+	}
+	defer rows.Close()
+
+	var messages []ConversationMessage
+	for rows.Next() {
+		var msg ConversationMessage
+		var parentID, agent, phase, provider, model sql.NullString
+		var tokens sql.NullInt64
+		if err := rows.Scan(&msg.ID, &parentID, &msg.Role, &agent, &phase, &provider, &model, &msg.Content, &tokens, &msg.Timestamp); err != nil {
+			return nil, fmt.Errorf("scan conversation %s: %w", conversationID, err)
+		}
+		msg.ParentID, msg.Agent, msg.Phase, msg.Provider, msg.Model = parentID.String, agent.String, phase.String, provider.String, model.String
+		msg.Tokens = int(tokens.Int64)
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// Truncate deletes every message after (and not including) afterMessageID.
+// An empty afterMessageID deletes the whole conversation.
+func (s *SQLiteConversationStore) Truncate(conversationID, afterMessageID string) error {
+	if afterMessageID == "" {
+		_, err := s.db.Exec(`DELETE FROM conversation_messages WHERE conversation_id = ?`, conversationID)
+		return err
+	}
+
+	var cutSeq int
+	row := s.db.QueryRow(`SELECT seq FROM conversation_messages WHERE conversation_id = ? AND id = ?`, conversationID, afterMessageID)
+	if err := row.Scan(&cutSeq); err != nil {
+		return fmt.Errorf("truncate conversation %s: find message %s: %w", conversationID, afterMessageID, err)
+	}
+
+	_, err := s.db.Exec(`DELETE FROM conversation_messages WHERE conversation_id = ? AND seq > ?`, conversationID, cutSeq)
+	return err
+}
+
+// List returns the IDs of every conversation with at least one persisted
+// message.
+func (s *SQLiteConversationStore) List() ([]string, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT conversation_id FROM conversation_messages`)
+	if err != nil {
+		return nil, fmt.Errorf("list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteConversationStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,223 @@
+package breeze
+
+import (
+	"testing"
+)
+
+func TestFileConversationStore_ResumeAfterCrash(t *testing.T) {
+	dir := t.TempDir()
+
+	first := NewFileConversationStore(dir)
+	if err := first.Append("sess1", ConversationMessage{ID: "m1", Role: "user", Content: "hello"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	// Simulate a crash: a brand new store instance pointed at the same
+	// directory should see everything the old one wrote.
+	resumed := NewFileConversationStore(dir)
+	if err := resumed.Append("sess1", ConversationMessage{ID: "m2", ParentID: "m1", Role: "assistant", Content: "hi there"}); err != nil {
+		t.Fatalf("Append after resume failed: %v", err)
+	}
+
+	messages, err := resumed.Messages("sess1")
+	if err != nil {
+		t.Fatalf("Messages failed: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages after resume, got %d", len(messages))
+	}
+	if messages[0].ID != "m1" || messages[1].ID != "m2" {
+		t.Errorf("Expected messages in append order [m1, m2], got [%s, %s]", messages[0].ID, messages[1].ID)
+	}
+}
+
+func TestConversation_Fork(t *testing.T) {
+	store := NewFileConversationStore(t.TempDir())
+	conv := &Conversation{ID: "base", store: store}
+
+	for _, msg := range []ConversationMessage{
+		{ID: "m1", Role: "user", Content: "first question"},
+		{ID: "m2", ParentID: "m1", Role: "assistant", Content: "first answer"},
+		{ID: "m3", ParentID: "m2", Role: "user", Content: "second question"},
+	} {
+		if err := store.Append("base", msg); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	fork, err := conv.Fork("m2")
+	if err != nil {
+		t.Fatalf("Fork failed: %v", err)
+	}
+
+	forked, err := fork.View()
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	if len(forked) != 2 {
+		t.Fatalf("Expected fork to contain messages up to and including m2, got %d messages", len(forked))
+	}
+
+	// Continuing the fork must not affect the original conversation.
+	if err := fork.store.Append(fork.ID, ConversationMessage{ID: "m3", ParentID: "m2", Role: "user", Content: "alternate question"}); err != nil {
+		t.Fatalf("Append to fork failed: %v", err)
+	}
+
+	original, err := conv.View()
+	if err != nil {
+		t.Fatalf("View of original failed: %v", err)
+	}
+	if len(original) != 3 {
+		t.Errorf("Expected original conversation to still have 3 messages, got %d", len(original))
+	}
+	if original[2].Content != "second question" {
+		t.Errorf("Expected original's third message unchanged, got %q", original[2].Content)
+	}
+}
+
+func TestConversation_ForkTwiceFromSameMessage(t *testing.T) {
+	store := NewFileConversationStore(t.TempDir())
+	conv := &Conversation{ID: "base", store: store}
+
+	for _, msg := range []ConversationMessage{
+		{ID: "m1", Role: "user", Content: "first question"},
+		{ID: "m2", ParentID: "m1", Role: "assistant", Content: "first answer"},
+	} {
+		if err := store.Append("base", msg); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	forkA, err := conv.Fork("m2")
+	if err != nil {
+		t.Fatalf("First Fork failed: %v", err)
+	}
+	if err := forkA.store.Append(forkA.ID, ConversationMessage{ID: "m3a", ParentID: "m2", Role: "user", Content: "branch A"}); err != nil {
+		t.Fatalf("Append to forkA failed: %v", err)
+	}
+
+	forkB, err := conv.Fork("m2")
+	if err != nil {
+		t.Fatalf("Second Fork failed: %v", err)
+	}
+	if err := forkB.store.Append(forkB.ID, ConversationMessage{ID: "m3b", ParentID: "m2", Role: "user", Content: "branch B"}); err != nil {
+		t.Fatalf("Append to forkB failed: %v", err)
+	}
+
+	if forkA.ID == forkB.ID {
+		t.Fatalf("Expected two forks of the same message to get distinct IDs, both got %q", forkA.ID)
+	}
+
+	viewA, err := forkA.View()
+	if err != nil {
+		t.Fatalf("View of forkA failed: %v", err)
+	}
+	if len(viewA) != 3 || viewA[2].Content != "branch A" {
+		t.Fatalf("Expected forkA to be [m1, m2, branch A], got %+v", viewA)
+	}
+
+	viewB, err := forkB.View()
+	if err != nil {
+		t.Fatalf("View of forkB failed: %v", err)
+	}
+	if len(viewB) != 3 || viewB[2].Content != "branch B" {
+		t.Fatalf("Expected forkB to be [m1, m2, branch B], got %+v", viewB)
+	}
+}
+
+func TestConversation_ForkUnknownMessage(t *testing.T) {
+	store := NewFileConversationStore(t.TempDir())
+	conv := &Conversation{ID: "base", store: store}
+	if err := store.Append("base", ConversationMessage{ID: "m1", Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	if _, err := conv.Fork("does-not-exist"); err == nil {
+		t.Error("Expected Fork to fail for an unknown message ID")
+	}
+}
+
+func TestFileConversationStore_Truncate(t *testing.T) {
+	store := NewFileConversationStore(t.TempDir())
+	for _, msg := range []ConversationMessage{
+		{ID: "m1", Role: "user", Content: "first question"},
+		{ID: "m2", ParentID: "m1", Role: "assistant", Content: "first answer"},
+		{ID: "m3", ParentID: "m2", Role: "user", Content: "second question"},
+	} {
+		if err := store.Append("base", msg); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	if err := store.Truncate("base", "m1"); err != nil {
+		t.Fatalf("Truncate failed: %v", err)
+	}
+
+	messages, err := store.Messages("base")
+	if err != nil {
+		t.Fatalf("Messages failed: %v", err)
+	}
+	if len(messages) != 1 || messages[0].ID != "m1" {
+		t.Fatalf("Expected only m1 to survive truncation, got %+v", messages)
+	}
+
+	if err := store.Truncate("base", ""); err != nil {
+		t.Fatalf("Truncate to empty failed: %v", err)
+	}
+	if messages, err := store.Messages("base"); err != nil || len(messages) != 0 {
+		t.Fatalf("Expected no messages after truncating to empty, got %+v (err %v)", messages, err)
+	}
+}
+
+func TestInMemoryConversationStore_AppendMessagesList(t *testing.T) {
+	store := NewInMemoryConversationStore()
+	if err := store.Append("sess1", ConversationMessage{ID: "m1", Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := store.Append("sess1", ConversationMessage{ID: "m2", ParentID: "m1", Role: "assistant", Content: "hello"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	messages, err := store.Messages("sess1")
+	if err != nil {
+		t.Fatalf("Messages failed: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+
+	ids, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "sess1" {
+		t.Fatalf("Expected List to report [sess1], got %v", ids)
+	}
+}
+
+func TestConversation_EditAndResendRejectsNonUserMessage(t *testing.T) {
+	store := NewInMemoryConversationStore()
+	conv := &Conversation{ID: "base", store: store}
+	if err := store.Append("base", ConversationMessage{ID: "m1", Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := store.Append("base", ConversationMessage{ID: "m2", ParentID: "m1", Role: "assistant", Content: "hello"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	if _, err := conv.EditAndResend("m2", "edited"); err == nil {
+		t.Error("Expected EditAndResend to reject editing a non-user message")
+	}
+}
+
+func TestConversation_EditAndResendUnknownMessage(t *testing.T) {
+	store := NewInMemoryConversationStore()
+	conv := &Conversation{ID: "base", store: store}
+	if err := store.Append("base", ConversationMessage{ID: "m1", Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	if _, err := conv.EditAndResend("does-not-exist", "edited"); err == nil {
+		t.Error("Expected EditAndResend to fail for an unknown message ID")
+	}
+}
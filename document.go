@@ -0,0 +1,516 @@
+package breeze
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DocFormat names a document format extractTextFromFile knows how to parse,
+// for callers that want to force a parser via WithDocFormat rather than
+// relying on sniffDocFormat's detection.
+type DocFormat int
+
+const (
+	// FormatAuto lets sniffDocFormat pick a format from content and extension.
+	FormatAuto DocFormat = iota
+	FormatTXT
+	FormatPDF
+	FormatDOCX
+	FormatMarkdown
+	FormatHTML
+	FormatRTF
+	FormatPPTX
+	FormatXLSX
+)
+
+// WithDocFormat forces extractTextFromFile to use a specific parser instead
+// of sniffing the file's format, e.g. for a ".txt"-named file that's
+// actually HTML.
+func WithDocFormat(format DocFormat) Option {
+	return func(opts *RequestOptions) {
+		opts.DocFormat = format
+	}
+}
+
+// OCREngine recognizes text in a raster image, used as a fallback when a PDF
+// page has no extractable text (e.g. a scanned document).
+type OCREngine interface {
+	Recognize(imageData []byte) (string, error)
+}
+
+// TesseractOCREngine shells out to the Tesseract OCR binary. It's the
+// default OCREngine; callers without Tesseract installed get an error back
+// from Recognize, which extractTextFromPDF treats as "no text on this page"
+// rather than a fatal error.
+type TesseractOCREngine struct {
+	// BinaryPath is the tesseract executable to run; defaults to "tesseract"
+	// on PATH.
+	BinaryPath string
+}
+
+func (e TesseractOCREngine) binary() string {
+	if e.BinaryPath != "" {
+		return e.BinaryPath
+	}
+	return "tesseract"
+}
+
+// Recognize writes imageData to a temp file and runs `tesseract <file> stdout`.
+func (e TesseractOCREngine) Recognize(imageData []byte) (string, error) {
+	tmp, err := os.CreateTemp("", "breeze-ocr-*.img")
+	if err != nil {
+		return "", fmt.Errorf("ocr: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.Write(imageData); err != nil {
+		return "", fmt.Errorf("ocr: write temp file: %w", err)
+	}
+
+	out, err := exec.Command(e.binary(), tmp.Name(), "stdout").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("ocr: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return string(out), nil
+}
+
+// WithOCR sets the OCR engine extractTextFromPDF falls back to when a PDF
+// page yields no extractable text. Defaults to TesseractOCREngine{}.
+func WithOCR(engine OCREngine) Option {
+	return func(opts *RequestOptions) {
+		opts.OCREngine = engine
+	}
+}
+
+// docOptions returns the options governing a document extraction call: the
+// first of opts if the caller supplied one (AI/Chat thread their
+// RequestOptions through), or zero-value defaults otherwise. FormatAuto and
+// a nil OCREngine mean "sniff the format" and "no OCR fallback".
+func docOptions(opts []RequestOptions) RequestOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return RequestOptions{}
+}
+
+// ocrEngine returns options.OCREngine, defaulting to TesseractOCREngine{}.
+func ocrEngine(options RequestOptions) OCREngine {
+	if options.OCREngine != nil {
+		return options.OCREngine
+	}
+	return TesseractOCREngine{}
+}
+
+// sniffDocFormat determines filePath's format from its content (via
+// net/http.DetectContentType and format-specific magic bytes) with the file
+// extension as a fallback, since DOCX/PPTX/XLSX are all ZIP archives that
+// only differ by the paths inside them.
+func sniffDocFormat(filePath string, data []byte) DocFormat {
+	lower := strings.ToLower(filePath)
+
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		return FormatPDF
+	}
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return sniffZipDocFormat(data, lower)
+	}
+	if strings.Contains(http.DetectContentType(data), "html") {
+		return FormatHTML
+	}
+
+	switch {
+	case strings.HasSuffix(lower, ".pdf"):
+		return FormatPDF
+	case strings.HasSuffix(lower, ".docx"):
+		return FormatDOCX
+	case strings.HasSuffix(lower, ".pptx"):
+		return FormatPPTX
+	case strings.HasSuffix(lower, ".xlsx"):
+		return FormatXLSX
+	case strings.HasSuffix(lower, ".md") || strings.HasSuffix(lower, ".markdown"):
+		return FormatMarkdown
+	case strings.HasSuffix(lower, ".html") || strings.HasSuffix(lower, ".htm"):
+		return FormatHTML
+	case strings.HasSuffix(lower, ".rtf"):
+		return FormatRTF
+	case strings.HasSuffix(lower, ".txt"):
+		return FormatTXT
+	default:
+		return FormatAuto
+	}
+}
+
+// sniffZipDocFormat distinguishes the Office Open XML formats, which share
+// the ZIP signature, by the presence of their format-specific member files.
+func sniffZipDocFormat(data []byte, lowerPath string) DocFormat {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err == nil {
+		for _, f := range zipReader.File {
+			switch {
+			case f.Name == "word/document.xml":
+				return FormatDOCX
+			case strings.HasPrefix(f.Name, "ppt/slides/"):
+				return FormatPPTX
+			case strings.HasPrefix(f.Name, "xl/worksheets/"):
+				return FormatXLSX
+			}
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lowerPath, ".pptx"):
+		return FormatPPTX
+	case strings.HasSuffix(lowerPath, ".xlsx"):
+		return FormatXLSX
+	default:
+		return FormatDOCX
+	}
+}
+
+// extractTextFromFile extracts text content from a document, dispatching on
+// sniffDocFormat unless opts carries a forced DocFormat via WithDocFormat.
+func extractTextFromFile(filePath string, opts ...RequestOptions) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %v", filePath, err)
+	}
+
+	options := docOptions(opts)
+	format := options.DocFormat
+	if format == FormatAuto {
+		format = sniffDocFormat(filePath, data)
+	}
+
+	switch format {
+	case FormatTXT:
+		return string(data), nil
+	case FormatPDF:
+		return extractTextFromPDF(data, options)
+	case FormatDOCX:
+		return extractTextFromDOCX(data)
+	case FormatPPTX:
+		return extractTextFromPPTX(data)
+	case FormatXLSX:
+		return extractTextFromXLSX(data)
+	case FormatMarkdown:
+		return extractTextFromMarkdown(data), nil
+	case FormatHTML:
+		return extractTextFromHTML(data), nil
+	case FormatRTF:
+		return extractTextFromRTF(data), nil
+	default:
+		return "", fmt.Errorf("unsupported file format: %s", filePath)
+	}
+}
+
+// pdfStreamPattern matches a PDF object's dictionary plus its stream body,
+// capturing the dictionary (to check /Filter and /Subtype) and the raw bytes
+// between the "stream" and "endstream" keywords.
+var pdfStreamPattern = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n(.*?)\r?\n?endstream`)
+
+// extractTextFromPDF extracts text from a PDF by decoding each object's
+// stream (inflating /FlateDecode content) and scanning content streams for
+// the Tj/TJ text-showing operators. Scanned pages with no extractable text
+// fall back to options.OCREngine (TesseractOCREngine by default) run over
+// any embedded /DCTDecode (JPEG) image XObjects.
+func extractTextFromPDF(data []byte, opts ...RequestOptions) (string, error) {
+	options := docOptions(opts)
+	var text strings.Builder
+	var images [][]byte
+
+	for _, m := range pdfStreamPattern.FindAllSubmatch(data, -1) {
+		dict, raw := string(m[1]), m[2]
+
+		body := raw
+		if strings.Contains(dict, "/FlateDecode") {
+			if inflated, err := inflate(raw); err == nil {
+				body = inflated
+			} else {
+				continue
+			}
+		}
+
+		if strings.Contains(dict, "/Image") {
+			if strings.Contains(dict, "/DCTDecode") {
+				images = append(images, body)
+			}
+			continue
+		}
+
+		extractPDFContentStreamText(&text, body)
+	}
+
+	if strings.TrimSpace(text.String()) == "" && len(images) > 0 {
+		engine := ocrEngine(options)
+		for _, img := range images {
+			if recognized, err := engine.Recognize(img); err == nil {
+				text.WriteString(recognized)
+				text.WriteString(" ")
+			}
+		}
+	}
+
+	return strings.TrimSpace(text.String()), nil
+}
+
+// pdfTextOperatorPattern matches the operands of the Tj and ' text-showing
+// operators (a single parenthesized string) and the TJ operator (an array of
+// strings and kerning numbers).
+var pdfTextOperatorPattern = regexp.MustCompile(`(?s)\((?:\\.|[^()\\])*\)\s*T[Jj']|\[(?:[^\]])*\]\s*TJ`)
+
+// extractPDFContentStreamText scans a decoded PDF content stream for
+// Tj/TJ/' text-showing operators and appends the literal strings they show,
+// unescaping PDF string escapes (\n, \(, \), \\, octal \ddd).
+func extractPDFContentStreamText(out *strings.Builder, content []byte) {
+	for _, match := range pdfTextOperatorPattern.FindAll(content, -1) {
+		for _, lit := range pdfStringLiteralPattern.FindAllSubmatch(match, -1) {
+			out.WriteString(unescapePDFString(lit[1]))
+		}
+		out.WriteString(" ")
+	}
+}
+
+var pdfStringLiteralPattern = regexp.MustCompile(`(?s)\((?:\\.|[^()\\])*\)`)
+
+// unescapePDFString resolves PDF string-literal escapes in a (...) operand,
+// given with its surrounding parentheses.
+func unescapePDFString(lit []byte) string {
+	s := lit[1 : len(lit)-1]
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			out.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			out.WriteByte('\n')
+		case 'r':
+			out.WriteByte('\r')
+		case 't':
+			out.WriteByte('\t')
+		case '(', ')', '\\':
+			out.WriteByte(s[i])
+		default:
+			if s[i] >= '0' && s[i] <= '7' {
+				end := i + 1
+				for end < len(s) && end < i+3 && s[end] >= '0' && s[end] <= '7' {
+					end++
+				}
+				if code, err := strconv.ParseInt(string(s[i:end]), 8, 32); err == nil {
+					out.WriteByte(byte(code))
+				}
+				i = end - 1
+			} else {
+				out.WriteByte(s[i])
+			}
+		}
+	}
+	return out.String()
+}
+
+// inflate decompresses a /FlateDecode PDF stream body.
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// docxTextWalker walks an Office Open XML part (document.xml, a header/footer
+// part, a slide, or a worksheet) and collects the character data of every
+// element whose local name matches localName, regardless of namespace or
+// nesting depth. Matching by local name alone means text inside drawings and
+// text boxes (e.g. DOCX's w:txbxContent) is picked up for free.
+func docxTextWalker(r io.Reader, localName string) (string, error) {
+	decoder := xml.NewDecoder(r)
+	var text strings.Builder
+	inTarget := 0
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("parse xml: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == localName {
+				inTarget++
+			}
+		case xml.EndElement:
+			if t.Name.Local == localName {
+				inTarget--
+			}
+		case xml.CharData:
+			if inTarget > 0 {
+				text.Write(t)
+			}
+		}
+	}
+
+	return text.String(), nil
+}
+
+// extractTextFromDOCX extracts text from a DOCX (a ZIP of XML parts),
+// pulling the body from word/document.xml and any header/footer parts so
+// headers, footers, and text boxes aren't silently dropped.
+func extractTextFromDOCX(data []byte) (string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read DOCX as ZIP: %v", err)
+	}
+
+	var parts strings.Builder
+	for _, file := range zipReader.File {
+		name := file.Name
+		if name != "word/document.xml" &&
+			!strings.HasPrefix(name, "word/header") &&
+			!strings.HasPrefix(name, "word/footer") {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open %s: %v", name, err)
+		}
+		text, err := docxTextWalker(rc, "t")
+		rc.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %v", name, err)
+		}
+
+		parts.WriteString(text)
+		parts.WriteString(" ")
+	}
+
+	if parts.Len() == 0 {
+		return "", fmt.Errorf("document.xml not found in DOCX")
+	}
+	return strings.TrimSpace(parts.String()), nil
+}
+
+// extractTextFromPPTX extracts slide text (the "a:t" runs) from every slide
+// in ppt/slides/.
+func extractTextFromPPTX(data []byte) (string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read PPTX as ZIP: %v", err)
+	}
+
+	var parts strings.Builder
+	for _, file := range zipReader.File {
+		if !strings.HasPrefix(file.Name, "ppt/slides/slide") || !strings.HasSuffix(file.Name, ".xml") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open %s: %v", file.Name, err)
+		}
+		text, err := docxTextWalker(rc, "t")
+		rc.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %v", file.Name, err)
+		}
+		parts.WriteString(text)
+		parts.WriteString(" ")
+	}
+
+	return strings.TrimSpace(parts.String()), nil
+}
+
+// extractTextFromXLSX extracts cell text from xl/sharedStrings.xml, which
+// covers the common case of text cells; numeric-only workbooks yield no text.
+func extractTextFromXLSX(data []byte) (string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read XLSX as ZIP: %v", err)
+	}
+
+	for _, file := range zipReader.File {
+		if file.Name != "xl/sharedStrings.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open %s: %v", file.Name, err)
+		}
+		text, err := docxTextWalker(rc, "t")
+		rc.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %v", file.Name, err)
+		}
+		return strings.TrimSpace(text), nil
+	}
+
+	return "", nil
+}
+
+var htmlTagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+
+// extractTextFromHTML strips tags, returning the remaining visible text.
+func extractTextFromHTML(data []byte) string {
+	text := htmlTagPattern.ReplaceAll(data, []byte(" "))
+	text = bytes.ReplaceAll(text, []byte("&amp;"), []byte("&"))
+	text = bytes.ReplaceAll(text, []byte("&lt;"), []byte("<"))
+	text = bytes.ReplaceAll(text, []byte("&gt;"), []byte(">"))
+	text = bytes.ReplaceAll(text, []byte("&nbsp;"), []byte(" "))
+	return strings.TrimSpace(strings.Join(strings.Fields(string(text)), " "))
+}
+
+var rtfControlWordPattern = regexp.MustCompile(`\\[a-zA-Z]+-?\d*\s?|[{}]`)
+
+// extractTextFromRTF strips control words and group braces, leaving the
+// plain-text content of the document.
+func extractTextFromRTF(data []byte) string {
+	text := rtfControlWordPattern.ReplaceAll(data, []byte(""))
+	return strings.TrimSpace(strings.Join(strings.Fields(string(text)), " "))
+}
+
+// extractTextFromMarkdown is largely already plain text; this just collapses
+// the whitespace for consistency with the other extractors.
+func extractTextFromMarkdown(data []byte) string {
+	return strings.TrimSpace(string(data))
+}
+
+// processDocuments extracts text from all provided document files, threading
+// opts (WithOCR/WithDocFormat) through to each extraction.
+func processDocuments(filePaths []string, opts ...RequestOptions) (string, error) {
+	if len(filePaths) == 0 {
+		return "", nil
+	}
+
+	var allText strings.Builder
+	allText.WriteString("\n--- Document Context ---\n")
+
+	for _, filePath := range filePaths {
+		text, err := extractTextFromFile(filePath, opts...)
+		if err != nil {
+			return "", fmt.Errorf("error processing %s: %v", filePath, err)
+		}
+
+		allText.WriteString(fmt.Sprintf("\nFile: %s\n", filePath))
+		allText.WriteString(text)
+		allText.WriteString("\n\n")
+	}
+
+	allText.WriteString("--- End Document Context ---\n")
+	return allText.String(), nil
+}
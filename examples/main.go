@@ -20,6 +20,7 @@ var registry = map[string]struct {
 	"symbolic_integration_collab": {"Collaborative symbolic integration of a complex expression", ex.RunSymbolicIntegrationCollab},
 	"single_vs_collab_comparison": {"Single LLM vs Collaborative approach comparison", ex.RunSingleVsCollabComparison},
 	"webapp_truck_simulation":     {"Collaborative web app development for truck simulation", ex.RunWebAppTruckSimulation},
+	"scaletest_collab":            {"Load-test a CollaborationMethod against a mocked AI backend", ex.RunScaletestCollab},
 }
 
 func main() {
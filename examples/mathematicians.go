@@ -79,11 +79,13 @@ Create a comprehensive mathematical solution including:
 Present this as a formal mathematical proof suitable for publication.`,
 			IsParallel:     false, // Sequential for coherence
 			MaxConcurrency: 1,
+			Verifier:       breeze.FunctionalEquationVerifier{},
 		},
 	}
 
 	// Create and run collaboration
 	collab := breeze.NewCollaboration(agents, phases)
+	collab.MaxRepairRounds = 2
 
 	// Add progress callbacks for fun user experience
 	collab.OnPhaseComplete = func(phaseName string, results map[string]string) {
@@ -9,8 +9,14 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +33,73 @@ type Review struct {
 	Strengths   []string
 	Weaknesses  []string
 	Suggestions []string
+	// Weight is the reviewer's breeze.Agent.Seniority at the time the review
+	// was conducted, with 0 normalized to 1 (an unweighted vote). Recorded on
+	// the review itself so consolidation and the final report can be
+	// recomputed or audited without re-looking-up the reviewer's agent.
+	Weight float64
+	// CriterionScores holds the reviewer's raw per-Criterion.Name score, on
+	// that criterion's own 0-MaxScore scale. Empty when the reviewer's
+	// structured response didn't parse and Score fell back to the flat
+	// free-text heuristic.
+	CriterionScores map[string]int
+}
+
+// Criterion is one dimension of a Rubric that reviewers score independently,
+// e.g. "Correctness" or "API Design".
+type Criterion struct {
+	Name        string
+	Description string
+	// Weight determines this criterion's share of the overall score;
+	// weights need not sum to 1 since scoreRubric normalizes by total weight.
+	Weight float64
+	// MaxScore is the top of this criterion's own scale (reviewers score
+	// 0-MaxScore), letting criteria use different scales and still combine
+	// fairly via scoreRubric.
+	MaxScore int
+}
+
+// Rubric is the set of criteria conductPeerReview asks each reviewer to
+// score independently, instead of a single opaque 1-10 rating.
+type Rubric []Criterion
+
+// defaultRubric covers the dimensions this example's demos have always
+// cared about, so existing callers that don't build a custom Rubric get
+// equivalent behavior to the old flat 1-10 score.
+func defaultRubric() Rubric {
+	return Rubric{
+		{Name: "Correctness", Description: "Does the solution actually satisfy the challenge's requirements?", Weight: 1, MaxScore: 10},
+		{Name: "Architecture", Description: "Is the design well-structured, modular, and maintainable?", Weight: 1, MaxScore: 10},
+		{Name: "Testability", Description: "How easily can the solution be tested and verified?", Weight: 1, MaxScore: 10},
+		{Name: "Performance", Description: "Does the solution consider efficiency and scale appropriately?", Weight: 1, MaxScore: 10},
+		{Name: "API Design", Description: "Are interfaces and contracts clear, consistent, and easy to use correctly?", Weight: 1, MaxScore: 10},
+	}
+}
+
+// ReportFormat selects how generateFinalReport renders a consolidated
+// assessment.
+type ReportFormat int
+
+const (
+	// FormatMarkdown renders the human-readable prose report (the default).
+	FormatMarkdown ReportFormat = iota
+	// FormatJSON renders a machine-readable document, e.g. for a CI
+	// pipeline that wants to gate on a score threshold.
+	FormatJSON
+	// FormatHTML renders a standalone HTML page of the same document.
+	FormatHTML
+)
+
+// extension returns the file extension saveReportToFile uses for f.
+func (f ReportFormat) extension() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatHTML:
+		return "html"
+	default:
+		return "md"
+	}
 }
 
 // WorkItem represents a piece of work by an agent
@@ -34,6 +107,10 @@ type WorkItem struct {
 	AgentName string
 	Work      string
 	Reviews   []Review
+	// Artifacts holds fenced code blocks extracted from Work by
+	// extractCodeBlocksMiddleware, keyed by the path named in their
+	// "**path/to/file.ext:**" header.
+	Artifacts map[string][]byte
 }
 
 // SWEngineeringCollab manages SW engineering team collaboration with peer review
@@ -42,83 +119,258 @@ type SWEngineeringCollab struct {
 	Challenge  string
 	WorkItems  map[string]*WorkItem
 	OnProgress func(current, total int, phase string)
-	mu         sync.RWMutex
+	// Rubric is the set of criteria peer reviews are scored against. Set by
+	// NewSWEngineeringCollab to defaultRubric() unless WithRubric overrides it.
+	Rubric Rubric
+	// Format selects generateFinalReport's output; defaults to FormatMarkdown.
+	Format ReportFormat
+	mu     sync.RWMutex
+	// runID timestamps this run's workspace directory (see workspaceDir) so
+	// extracted artifacts and the final report land side by side.
+	runID string
+
+	// maxConcurrency bounds how many agents (Phase 1) or reviewer pairs
+	// (Phase 2) run in parallel at once. <= 0 means unbounded, one goroutine
+	// per agent/pair. Set via WithConcurrency.
+	maxConcurrency int
+	// reviewTimeout caps how long a single AI call is allowed to run before
+	// callAI treats it as a transient failure and retries. Zero means no
+	// timeout. Set via WithReviewTimeout.
+	reviewTimeout time.Duration
+	// maxRetries is how many additional attempts callAI makes after an AI
+	// call comes back as an "Error: ..." response, so one transient model
+	// hiccup doesn't abort an entire run. Set via WithMaxRetries.
+	maxRetries int
+	// aiClient is the function callAI wraps for every non-structured AI call
+	// in this collaboration. Defaults to breeze.AI; overridable via
+	// WithAIClient for tests or to point at a different model/backend.
+	aiClient func(prompt string, opts ...breeze.Option) string
+
+	progressMu  sync.Mutex
+	currentStep int
+}
+
+// Option configures a SWEngineeringCollab built by NewSWEngineeringCollab.
+type Option func(*SWEngineeringCollab)
+
+// WithAgents sets the team that does individual work and peer review.
+func WithAgents(agents []breeze.Agent) Option {
+	return func(sec *SWEngineeringCollab) { sec.Agents = agents }
+}
+
+// WithConcurrency bounds how many agents (Phase 1) or reviewer pairs
+// (Phase 2) run in parallel. n <= 0 leaves the phase unbounded.
+func WithConcurrency(n int) Option {
+	return func(sec *SWEngineeringCollab) { sec.maxConcurrency = n }
 }
 
-// NewSWEngineeringCollab creates a new SW engineering collaboration
-func NewSWEngineeringCollab(agents []breeze.Agent, challenge string) *SWEngineeringCollab {
-	return &SWEngineeringCollab{
-		Agents:    agents,
-		Challenge: challenge,
-		WorkItems: make(map[string]*WorkItem),
+// WithReviewTimeout caps how long a single AI call is allowed to run before
+// it's treated as a transient failure and retried.
+func WithReviewTimeout(d time.Duration) Option {
+	return func(sec *SWEngineeringCollab) { sec.reviewTimeout = d }
+}
+
+// WithProgress sets the progress callback, equivalent to assigning
+// sec.OnProgress directly.
+func WithProgress(cb func(current, total int, phase string)) Option {
+	return func(sec *SWEngineeringCollab) { sec.OnProgress = cb }
+}
+
+// WithRubric overrides defaultRubric() with a custom set of criteria.
+func WithRubric(r Rubric) Option {
+	return func(sec *SWEngineeringCollab) { sec.Rubric = r }
+}
+
+// WithAIClient overrides the function used for every non-structured AI call
+// in this collaboration (individual work, free-text review fallback). It
+// does not affect the breeze.StructuredOutput call in conductPeerReview,
+// which always goes through breeze.AI directly.
+func WithAIClient(client func(prompt string, opts ...breeze.Option) string) Option {
+	return func(sec *SWEngineeringCollab) { sec.aiClient = client }
+}
+
+// WithMaxRetries sets how many additional attempts callAI makes after an AI
+// call comes back as a transient "Error: ..." response before giving up.
+func WithMaxRetries(n int) Option {
+	return func(sec *SWEngineeringCollab) { sec.maxRetries = n }
+}
+
+// NewSWEngineeringCollab creates a new SW engineering collaboration for
+// challenge, configured by opts. Supply the team via WithAgents; everything
+// else has a working default (defaultRubric(), unbounded concurrency, no
+// per-call timeout, 1 retry on a transient AI error).
+func NewSWEngineeringCollab(challenge string, opts ...Option) *SWEngineeringCollab {
+	sec := &SWEngineeringCollab{
+		Challenge:  challenge,
+		WorkItems:  make(map[string]*WorkItem),
+		Rubric:     defaultRubric(),
+		maxRetries: 1,
+		aiClient:   breeze.AI,
+	}
+	for _, opt := range opts {
+		opt(sec)
 	}
+	return sec
+}
+
+// callAI runs prompt through sec.aiClient, enforcing sec.reviewTimeout (if
+// set) and retrying up to sec.maxRetries times when the result looks like
+// one of breeze.AI's "Error: ..." failure strings, so a transient model
+// error doesn't abort a run hours into a peer-review matrix.
+func (sec *SWEngineeringCollab) callAI(prompt string, opts ...breeze.Option) string {
+	var result string
+	for attempt := 0; attempt <= sec.maxRetries; attempt++ {
+		result = sec.callAIOnce(prompt, opts...)
+		if !strings.HasPrefix(result, "Error:") {
+			return result
+		}
+	}
+	return result
 }
 
-// Run executes the complete SW engineering workflow
+// callAIOnce runs a single AI call, cancelling and reporting a timeout error
+// if sec.reviewTimeout elapses before sec.aiClient returns.
+func (sec *SWEngineeringCollab) callAIOnce(prompt string, opts ...breeze.Option) string {
+	if sec.reviewTimeout <= 0 {
+		return sec.aiClient(prompt, opts...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sec.reviewTimeout)
+	defer cancel()
+
+	result := make(chan string, 1)
+	go func() { result <- sec.aiClient(prompt, opts...) }()
+
+	select {
+	case r := <-result:
+		return r
+	case <-ctx.Done():
+		return fmt.Sprintf("Error: AI call timed out after %s", sec.reviewTimeout)
+	}
+}
+
+// runWithConcurrency runs fn(0), fn(1), ..., fn(n-1) concurrently, bounded by
+// sec.maxConcurrency (unbounded if <= 0), and blocks until all have
+// returned. Mirrors the WaitGroup+semaphore pattern breeze.Collaboration
+// uses in runParallelPhase.
+func (sec *SWEngineeringCollab) runWithConcurrency(n int, fn func(i int)) {
+	if n <= 0 {
+		return
+	}
+	limit := sec.maxConcurrency
+	if limit <= 0 {
+		limit = n
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, limit)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// advanceProgress atomically increments the shared step counter and reports
+// it via updateProgress, so the parallel phases still produce a monotonic
+// progress bar instead of racing on currentStep.
+func (sec *SWEngineeringCollab) advanceProgress(total int, phase string) {
+	sec.progressMu.Lock()
+	sec.currentStep++
+	current := sec.currentStep
+	sec.progressMu.Unlock()
+	sec.updateProgress(current, total, phase)
+}
+
+// workspaceDir is the per-run directory where extracted artifacts and the
+// final markdown report are written, e.g. "examples/sw_run_<timestamp>/".
+func (sec *SWEngineeringCollab) workspaceDir() string {
+	return filepath.Join("examples", fmt.Sprintf("sw_run_%s", sec.runID))
+}
+
+// Run executes the complete SW engineering workflow. Phase 1 (individual
+// work) and Phase 2 (the cross-reviewer matrix) each run with up to
+// sec.maxConcurrency goroutines in flight; sec.mu guards WorkItems and
+// advanceProgress guards the shared step counter against the resulting
+// concurrent writes.
 func (sec *SWEngineeringCollab) Run() error {
+	sec.runID = time.Now().Format("2006-01-02_15-04-05")
+	sec.currentStep = 0
+
 	totalSteps := len(sec.Agents)*2 + len(sec.Agents)*(len(sec.Agents)-1) + 2 // work + reviews + merge + final
-	currentStep := 0
 
-	// Phase 1: Each agent does their work
+	// Phase 1: Each agent does their work, in parallel
 	fmt.Println("Starting software engineering collaboration")
 	fmt.Println("📋 Phase 1: Individual Implementation")
-	sec.updateProgress(currentStep, totalSteps, "Individual Work")
+	sec.updateProgress(sec.currentStep, totalSteps, "Individual Work")
 
-	for _, agent := range sec.Agents {
+	sec.runWithConcurrency(len(sec.Agents), func(i int) {
+		agent := sec.Agents[i]
 		prompt := sec.buildWorkPrompt(agent)
-		work := breeze.AI(prompt, breeze.WithConcise())
+		work := sec.callAI(prompt, breeze.WithConcise())
 
-		sec.mu.Lock()
-		sec.WorkItems[agent.Name] = &WorkItem{
+		item := &WorkItem{
 			AgentName: agent.Name,
 			Work:      work,
 			Reviews:   []Review{},
 		}
+		item.Work = sec.applyPostStep(agent, "Individual Work", item, item.Work)
+
+		sec.mu.Lock()
+		sec.WorkItems[agent.Name] = item
 		sec.mu.Unlock()
 
-		currentStep++
-		sec.updateProgress(currentStep, totalSteps, fmt.Sprintf("Work: %s", agent.Name))
-	}
+		sec.advanceProgress(totalSteps, fmt.Sprintf("Work: %s", agent.Name))
+	})
 
-	// Phase 2: Peer review (each agent reviews others' work)
+	// Phase 2: Peer review (each agent reviews others' work), in parallel
 	fmt.Println("\n📋 Phase 2: Peer Review Process")
+	type reviewPair struct{ reviewer, reviewee breeze.Agent }
+	var pairs []reviewPair
 	for _, reviewer := range sec.Agents {
 		for _, reviewee := range sec.Agents {
 			if reviewer.Name != reviewee.Name {
-				review := sec.conductPeerReview(reviewer, reviewee)
-				sec.mu.Lock()
-				if item, exists := sec.WorkItems[reviewee.Name]; exists {
-					item.Reviews = append(item.Reviews, review)
-				}
-				sec.mu.Unlock()
-
-				currentStep++
-				sec.updateProgress(currentStep, totalSteps, fmt.Sprintf("Review: %s → %s", reviewer.Name, reviewee.Name))
+				pairs = append(pairs, reviewPair{reviewer, reviewee})
 			}
 		}
 	}
 
+	sec.runWithConcurrency(len(pairs), func(i int) {
+		pair := pairs[i]
+		review := sec.conductPeerReview(pair.reviewer, pair.reviewee)
+
+		sec.mu.Lock()
+		if item, exists := sec.WorkItems[pair.reviewee.Name]; exists {
+			item.Reviews = append(item.Reviews, review)
+		}
+		sec.mu.Unlock()
+
+		sec.advanceProgress(totalSteps, fmt.Sprintf("Review: %s → %s", pair.reviewer.Name, pair.reviewee.Name))
+	})
+
 	// Phase 3: Merge reviews and create final assessment
 	fmt.Println("\n📋 Phase 3: Review Consolidation")
 	finalAssessment := sec.consolidateReviews()
-
-	currentStep++
-	sec.updateProgress(currentStep, totalSteps, "Consolidating Reviews")
+	sec.advanceProgress(totalSteps, "Consolidating Reviews")
 
 	// Phase 4: Generate final report
 	fmt.Println("\n📋 Phase 4: Final Report Generation")
 	report := sec.generateFinalReport(finalAssessment)
+	sec.advanceProgress(totalSteps, "Generating Final Report")
 
-	currentStep++
-	sec.updateProgress(currentStep, totalSteps, "Generating Final Report")
-
-	// Save final report to file
+	// Save final report to file, alongside any artifacts extracted during the run
 	err := sec.saveReportToFile(report)
 	if err != nil {
 		return fmt.Errorf("failed to save report: %v", err)
 	}
 
-	fmt.Printf("\nCollaboration complete. Report saved to: sw_team_report_%s.md\n", time.Now().Format("2006-01-02_15-04-05"))
+	fmt.Printf("\nCollaboration complete. Report and artifacts saved to: %s\n", sec.workspaceDir())
 	return nil
 }
 
@@ -135,10 +387,31 @@ Your task is to provide a comprehensive solution to this challenge. Focus on:
 4. Potential challenges and solutions
 5. Best practices and standards
 
-Provide a detailed, actionable implementation plan.`, agent.Name, agent.Role, agent.Expertise, agent.Personality, sec.Challenge)
+Provide a detailed, actionable implementation plan. Where you include code, precede each fenced code block with a "**path/to/file.ext:**" header naming the file it belongs to, so it can be extracted as a standalone artifact.`, agent.Name, agent.Role, agent.Expertise, agent.Personality, sec.Challenge)
 }
 
-// conductPeerReview has one agent review another's work
+// structuredReview is the JSON shape requested from the model via
+// breeze.StructuredOutput in conductPeerReview. CriterionScores is keyed by
+// Criterion.Name, one raw score per rubric dimension.
+type structuredReview struct {
+	CriterionScores map[string]int `json:"criterion_scores"`
+	Strengths       []string       `json:"strengths"`
+	Weaknesses      []string       `json:"weaknesses"`
+	Suggestions     []string       `json:"suggestions"`
+	Comments        string         `json:"comments"`
+}
+
+// rubricPrompt describes each criterion's name, weight, and scale for the
+// reviewer prompt in conductPeerReview.
+func rubricPrompt(rubric Rubric) string {
+	var b strings.Builder
+	for _, c := range rubric {
+		fmt.Fprintf(&b, "- %s (weight %.1f, score 0-%d): %s\n", c.Name, c.Weight, c.MaxScore, c.Description)
+	}
+	return b.String()
+}
+
+// conductPeerReview has one agent review another's work against sec.Rubric
 func (sec *SWEngineeringCollab) conductPeerReview(reviewer, reviewee breeze.Agent) Review {
 	sec.mu.RLock()
 	workItem := sec.WorkItems[reviewee.Name]
@@ -150,19 +423,36 @@ REVIEWEE: %s (%s)
 WORK TO REVIEW:
 %s
 
-Please provide a structured peer review with:
-1. Overall Score (1-10, where 10 is excellent)
-2. Key Strengths (list 2-3)
-3. Areas for Improvement (list 1-3)
-4. Specific Suggestions (list 2-3)
-5. Overall Assessment
+Score this work independently against each of the following criteria (key the criterion_scores JSON field by the exact criterion name shown):
+%s
+Be constructive, specific, and professional in your feedback.`, reviewer.Name, reviewer.Role, reviewee.Name, reviewee.Role, workItem.Work, rubricPrompt(sec.Rubric))
 
-Be constructive, specific, and professional in your feedback.`, reviewer.Name, reviewer.Role, reviewee.Name, reviewee.Role, workItem.Work)
+	weight := reviewer.Seniority
+	if weight == 0 {
+		weight = 1
+	}
 
-	reviewText := breeze.AI(prompt, breeze.WithConcise())
+	if structured, err := breeze.StructuredOutput[structuredReview](prompt, 2, breeze.WithConcise()); err == nil {
+		return Review{
+			Reviewer:        reviewer.Name,
+			Reviewee:        reviewee.Name,
+			Score:           scoreRubric(sec.Rubric, structured.CriterionScores),
+			Comments:        sec.applyPostStep(reviewer, "Peer Review", workItem, structured.Comments),
+			Strengths:       structured.Strengths,
+			Weaknesses:      structured.Weaknesses,
+			Suggestions:     structured.Suggestions,
+			Weight:          weight,
+			CriterionScores: structured.CriterionScores,
+		}
+	}
 
-	// Parse the review (simplified parsing)
-	review := Review{
+	// Structured parsing didn't pan out after retries; fall back to the
+	// original free-text heuristic rather than losing the review entirely.
+	// The heuristic can't reliably attribute a score per criterion, so only
+	// the overall Score is populated.
+	reviewText := sec.callAI(prompt, breeze.WithConcise())
+	reviewText = sec.applyPostStep(reviewer, "Peer Review", workItem, reviewText)
+	return Review{
 		Reviewer:    reviewer.Name,
 		Reviewee:    reviewee.Name,
 		Score:       sec.extractScore(reviewText),
@@ -170,9 +460,252 @@ Be constructive, specific, and professional in your feedback.`, reviewer.Name, r
 		Strengths:   sec.extractListItems(reviewText, "strengths", "strength"),
 		Weaknesses:  sec.extractListItems(reviewText, "weaknesses", "improvement"),
 		Suggestions: sec.extractListItems(reviewText, "suggestions", "recommendations"),
+		Weight:      weight,
+	}
+}
+
+// applyPostStep runs the built-in PostStep pipeline (code-block extraction,
+// Go validation, artifact persistence) over an agent's raw output, mirroring
+// breeze.Phase.PostStep for this example's own (non-breeze.Collaboration)
+// workflow. A middleware error is logged and that step is skipped, keeping
+// the output from the previous step rather than losing the agent's turn.
+func (sec *SWEngineeringCollab) applyPostStep(agent breeze.Agent, phaseName string, item *WorkItem, output string) string {
+	ctx := breeze.PhaseContext{Phase: breeze.Phase{Name: phaseName}, Agent: agent}
+	pipeline := []breeze.PhaseMiddleware{
+		extractCodeBlocksMiddleware(item),
+		validateGoBlocksMiddleware(item),
+		sec.persistArtifactsMiddleware(item),
+	}
+
+	for _, mw := range pipeline {
+		out, err := mw(ctx, output)
+		if err != nil {
+			fmt.Printf("⚠️  PostStep middleware failed for %s/%s: %v\n", phaseName, agent.Name, err)
+			continue
+		}
+		output = out
+	}
+	return output
+}
+
+// extractCodeBlocksMiddleware extracts fenced code blocks preceded by a
+// "**path/to/file.ext:**" header line into item.Artifacts, keyed by path.
+// The output text itself passes through unchanged.
+func extractCodeBlocksMiddleware(item *WorkItem) breeze.PhaseMiddleware {
+	return func(ctx breeze.PhaseContext, output string) (string, error) {
+		for path, src := range extractFencedCodeBlocks(output) {
+			if item.Artifacts == nil {
+				item.Artifacts = make(map[string][]byte)
+			}
+			item.Artifacts[path] = src
+		}
+		return output, nil
+	}
+}
+
+// extractFencedCodeBlocks scans text for "**path:**" headers immediately
+// followed by a fenced code block and returns the block bodies keyed by path.
+func extractFencedCodeBlocks(text string) map[string][]byte {
+	artifacts := make(map[string][]byte)
+	lines := strings.Split(text, "\n")
+
+	var path string
+	var inBlock bool
+	var block strings.Builder
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inBlock:
+			if strings.HasPrefix(trimmed, "```") {
+				if path != "" {
+					artifacts[path] = []byte(block.String())
+				}
+				inBlock, path = false, ""
+				block.Reset()
+				continue
+			}
+			block.WriteString(line)
+			block.WriteString("\n")
+		case strings.HasPrefix(trimmed, "**") && strings.HasSuffix(trimmed, ":**"):
+			path = strings.TrimSuffix(strings.TrimPrefix(trimmed, "**"), ":**")
+		case strings.HasPrefix(trimmed, "```"):
+			inBlock = true
+		}
+	}
+
+	return artifacts
+}
+
+// validateGoBlocksMiddleware runs gofmt and go vet over any .go artifacts
+// extracted by extractCodeBlocksMiddleware and appends the diagnostics to
+// the output so peer reviewers see build/format issues before they review.
+func validateGoBlocksMiddleware(item *WorkItem) breeze.PhaseMiddleware {
+	return func(ctx breeze.PhaseContext, output string) (string, error) {
+		var diagnostics []string
+		for path, src := range item.Artifacts {
+			if !strings.HasSuffix(path, ".go") {
+				continue
+			}
+			if diag := goDiagnostics(path, src); diag != "" {
+				diagnostics = append(diagnostics, diag)
+			}
+		}
+		if len(diagnostics) == 0 {
+			return output, nil
+		}
+		return output + "\n\n#### Build Diagnostics\n" + strings.Join(diagnostics, "\n"), nil
+	}
+}
+
+// goDiagnostics writes src to a scratch file and runs gofmt -l and go vet
+// against it, returning a summary of anything they flagged, or "" if clean.
+func goDiagnostics(path string, src []byte) string {
+	tmp, err := os.MkdirTemp("", "breeze-goval-*")
+	if err != nil {
+		return fmt.Sprintf("- **%s**: could not create scratch dir for validation: %v", path, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	file := filepath.Join(tmp, filepath.Base(path))
+	if err := os.WriteFile(file, src, 0644); err != nil {
+		return fmt.Sprintf("- **%s**: could not write scratch file for validation: %v", path, err)
+	}
+
+	var issues []string
+	if out, err := exec.Command("gofmt", "-l", file).CombinedOutput(); err != nil {
+		issues = append(issues, fmt.Sprintf("gofmt failed to run: %v", err))
+	} else if strings.TrimSpace(string(out)) != "" {
+		issues = append(issues, "gofmt: needs formatting")
+	}
+
+	if out, err := exec.Command("go", "vet", file).CombinedOutput(); err != nil {
+		issues = append(issues, fmt.Sprintf("go vet: %s", strings.TrimSpace(string(out))))
 	}
 
-	return review
+	if len(issues) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("- **%s**: %s", path, strings.Join(issues, "; "))
+}
+
+// persistArtifactsMiddleware writes item.Artifacts under
+// sec.workspaceDir()/<agent>/, so extracted files sit alongside the final
+// markdown report for a completed run.
+func (sec *SWEngineeringCollab) persistArtifactsMiddleware(item *WorkItem) breeze.PhaseMiddleware {
+	return func(ctx breeze.PhaseContext, output string) (string, error) {
+		for path, src := range item.Artifacts {
+			dest := filepath.Join(sec.workspaceDir(), item.AgentName, path)
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return output, fmt.Errorf("persist artifact %s: %w", path, err)
+			}
+			if err := os.WriteFile(dest, src, 0644); err != nil {
+				return output, fmt.Errorf("persist artifact %s: %w", path, err)
+			}
+		}
+		return output, nil
+	}
+}
+
+// clampScore keeps a model-reported score within the documented 1-10 range.
+func clampScore(score int) int {
+	switch {
+	case score < 1:
+		return 1
+	case score > 10:
+		return 10
+	default:
+		return score
+	}
+}
+
+// scoreRubric combines per-criterion raw scores into a single 1-10 overall
+// score: each criterion is normalized to its own MaxScore so criteria with
+// different scales combine fairly, then weighted-averaged by Weight.
+func scoreRubric(rubric Rubric, scores map[string]int) int {
+	var weighted, totalWeight float64
+	for _, c := range rubric {
+		raw, ok := scores[c.Name]
+		if !ok || c.MaxScore == 0 {
+			continue
+		}
+		weighted += (float64(raw) / float64(c.MaxScore)) * c.Weight
+		totalWeight += c.Weight
+	}
+	if totalWeight == 0 {
+		return 7 // neutral default, matches extractScore's fallback
+	}
+	return clampScore(int(math.Round(weighted / totalWeight * 10)))
+}
+
+// studentT95Table is the two-tailed 95% critical t-value by degrees of
+// freedom, for the small-N confidence intervals a peer-review round
+// produces (one review per other team member, so N is rarely above a
+// handful). Beyond the table, studentT95 falls back to the normal
+// approximation the t-distribution converges to as df grows.
+var studentT95Table = map[int]float64{
+	1: 12.706, 2: 4.303, 3: 3.182, 4: 2.776, 5: 2.571,
+	6: 2.447, 7: 2.365, 8: 2.306, 9: 2.262, 10: 2.228,
+	11: 2.201, 12: 2.179, 13: 2.160, 14: 2.145, 15: 2.131,
+	16: 2.120, 17: 2.110, 18: 2.101, 19: 2.093, 20: 2.086,
+	21: 2.080, 22: 2.074, 23: 2.069, 24: 2.064, 25: 2.060,
+	26: 2.056, 27: 2.052, 28: 2.048, 29: 2.045, 30: 2.042,
+}
+
+// studentT95 returns the two-tailed 95% critical t-value for df degrees of
+// freedom, using the normal approximation (1.96) above the tabulated range.
+func studentT95(df int) float64 {
+	if df <= 0 {
+		return 0
+	}
+	if t, ok := studentT95Table[df]; ok {
+		return t
+	}
+	return 1.96
+}
+
+// sampleStddev returns the Bessel-corrected (N-1) sample standard deviation
+// of xs around mean m. Undefined (0) for fewer than 2 samples.
+func sampleStddev(xs []float64, m float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	sumSq := 0.0
+	for _, x := range xs {
+		d := x - m
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq / float64(len(xs)-1))
+}
+
+// confidenceInterval95 returns the 95% confidence interval half-width around
+// mean m for scores, via Student's t-distribution (appropriate for the
+// small N typical of one peer-review round), along with the sample stddev
+// the half-width and outlierReviews were derived from.
+func confidenceInterval95(scores []float64, m float64) (halfWidth, stddev float64) {
+	n := len(scores)
+	if n < 2 {
+		return 0, 0
+	}
+	stddev = sampleStddev(scores, m)
+	return studentT95(n-1) * stddev / math.Sqrt(float64(n)), stddev
+}
+
+// outlierReviews returns the reviews whose score has a |z-score| over 2
+// against mean m and stddev, flagging a reviewer whose score for this work
+// item looks like bias or noise rather than signal.
+func outlierReviews(reviews []Review, m, stddev float64) []Review {
+	if stddev == 0 {
+		return nil
+	}
+	var outliers []Review
+	for _, r := range reviews {
+		z := (float64(r.Score) - m) / stddev
+		if math.Abs(z) > 2 {
+			outliers = append(outliers, r)
+		}
+	}
+	return outliers
 }
 
 // extractScore extracts a numeric score from review text
@@ -206,7 +739,14 @@ func (sec *SWEngineeringCollab) extractListItems(text, keyword1, keyword2 string
 	return items
 }
 
-// consolidateReviews merges all reviews with scoring
+// contestedVarianceThreshold is the weighted-variance cutoff (in score^2,
+// on the 1-10 scale) above which reviewers disagreed enough that the
+// consensus score shouldn't be trusted without a tiebreaker round.
+const contestedVarianceThreshold = 4.0
+
+// consolidateReviews merges all reviews into a seniority-weighted score.
+// Reviewers with a higher breeze.Agent.Seniority pull the consensus score,
+// and which feedback surfaces, further toward their own assessment.
 func (sec *SWEngineeringCollab) consolidateReviews() map[string]map[string]interface{} {
 	assessment := make(map[string]map[string]interface{})
 
@@ -219,52 +759,148 @@ func (sec *SWEngineeringCollab) consolidateReviews() map[string]map[string]inter
 			continue
 		}
 
-		// Calculate average score
-		totalScore := 0
+		weightedScore, totalWeight := 0.0, 0.0
 		for _, review := range workItem.Reviews {
-			totalScore += review.Score
+			weightedScore += float64(review.Score) * review.Weight
+			totalWeight += review.Weight
 		}
-		avgScore := float64(totalScore) / float64(len(workItem.Reviews))
+		avgScore := weightedScore / totalWeight
 
-		// Collect all feedback
-		var allStrengths, allWeaknesses, allSuggestions []string
+		variance := 0.0
 		for _, review := range workItem.Reviews {
-			allStrengths = append(allStrengths, review.Strengths...)
-			allWeaknesses = append(allWeaknesses, review.Weaknesses...)
-			allSuggestions = append(allSuggestions, review.Suggestions...)
+			d := float64(review.Score) - avgScore
+			variance += review.Weight * d * d
+		}
+		variance /= totalWeight
+		contested := variance > contestedVarianceThreshold
+
+		scores := make([]float64, len(workItem.Reviews))
+		for i, review := range workItem.Reviews {
+			scores[i] = float64(review.Score)
+		}
+		ciHalfWidth, sampleSD := confidenceInterval95(scores, avgScore)
+		outliers := outlierReviews(workItem.Reviews, avgScore, sampleSD)
+
+		reviewWeights := make([]map[string]interface{}, len(workItem.Reviews))
+		for i, review := range workItem.Reviews {
+			reviewWeights[i] = map[string]interface{}{
+				"reviewer": review.Reviewer,
+				"weight":   review.Weight,
+				"score":    review.Score,
+			}
 		}
 
 		assessment[agent.Name] = map[string]interface{}{
-			"work":         workItem.Work,
-			"avg_score":    avgScore,
-			"review_count": len(workItem.Reviews),
-			"strengths":    sec.deduplicateList(allStrengths),
-			"weaknesses":   sec.deduplicateList(allWeaknesses),
-			"suggestions":  sec.deduplicateList(allSuggestions),
-			"reviews":      workItem.Reviews,
+			"work":             workItem.Work,
+			"avg_score":        avgScore,
+			"variance":         variance,
+			"contested":        contested,
+			"review_count":     len(workItem.Reviews),
+			"review_weights":   reviewWeights,
+			"ci_half_width":    ciHalfWidth,
+			"sample_stddev":    sampleSD,
+			"outliers":         outliers,
+			"criterion_scores": sec.consolidateCriterionScores(workItem.Reviews),
+			"strengths":        sec.weightedListItems(workItem.Reviews, func(r Review) []string { return r.Strengths }),
+			"weaknesses":       sec.weightedListItems(workItem.Reviews, func(r Review) []string { return r.Weaknesses }),
+			"suggestions":      sec.weightedListItems(workItem.Reviews, func(r Review) []string { return r.Suggestions }),
+			"reviews":          workItem.Reviews,
 		}
 	}
 
 	return assessment
 }
 
-// deduplicateList removes duplicate items from a list
-func (sec *SWEngineeringCollab) deduplicateList(items []string) []string {
-	seen := make(map[string]bool)
-	var result []string
+// consolidateCriterionScores computes, for each sec.Rubric criterion, the
+// seniority-weighted average of every review's raw score on that criterion
+// (reviews missing the criterion, e.g. from the free-text fallback, are
+// excluded rather than counted as zero).
+func (sec *SWEngineeringCollab) consolidateCriterionScores(reviews []Review) map[string]float64 {
+	scores := make(map[string]float64, len(sec.Rubric))
+
+	for _, c := range sec.Rubric {
+		weightedSum, totalWeight := 0.0, 0.0
+		for _, review := range reviews {
+			raw, ok := review.CriterionScores[c.Name]
+			if !ok {
+				continue
+			}
+			weightedSum += float64(raw) * review.Weight
+			totalWeight += review.Weight
+		}
+		if totalWeight > 0 {
+			scores[c.Name] = weightedSum / totalWeight
+		}
+	}
+
+	return scores
+}
 
-	for _, item := range items {
-		if item != "" && !seen[item] {
-			seen[item] = true
-			result = append(result, item)
+// weightedListItems deduplicates feedback items across reviews and orders
+// them by the cumulative seniority weight of the reviewers who raised them,
+// so the most senior-backed feedback surfaces first in the final report.
+func (sec *SWEngineeringCollab) weightedListItems(reviews []Review, get func(Review) []string) []string {
+	weight := make(map[string]float64)
+	var order []string
+
+	for _, review := range reviews {
+		for _, item := range get(review) {
+			if item == "" {
+				continue
+			}
+			if _, seen := weight[item]; !seen {
+				order = append(order, item)
+			}
+			weight[item] += review.Weight
 		}
 	}
 
-	return result
+	sort.SliceStable(order, func(i, j int) bool {
+		return weight[order[i]] > weight[order[j]]
+	})
+
+	return order
 }
 
 // generateFinalReport creates the comprehensive final report
 func (sec *SWEngineeringCollab) generateFinalReport(assessment map[string]map[string]interface{}) string {
+	switch sec.Format {
+	case FormatJSON:
+		return sec.generateJSONReport(assessment)
+	case FormatHTML:
+		return sec.generateHTMLReport(assessment)
+	default:
+		return sec.generateMarkdownReport(assessment)
+	}
+}
+
+// outlierAppendix renders the "Outlier Reviews" section listing every
+// review flagged by outlierReviews across all agents, so users can decide
+// whether to discard a biased reviewer.
+func (sec *SWEngineeringCollab) outlierAppendix(assessment map[string]map[string]interface{}) string {
+	section := "## Outlier Reviews (Possible Reviewer Bias)\n\n"
+	found := false
+
+	for _, agent := range sec.Agents {
+		data := assessment[agent.Name]
+		outliers, ok := data["outliers"].([]Review)
+		if !ok || len(outliers) == 0 {
+			continue
+		}
+		found = true
+		for _, o := range outliers {
+			section += fmt.Sprintf("- %s → %s: scored %d/10 (more than ~2σ from the %.1f/10 consensus)\n", o.Reviewer, agent.Name, o.Score, data["avg_score"])
+		}
+	}
+
+	if !found {
+		section += "No reviews were flagged as statistical outliers.\n"
+	}
+	return section + "\n"
+}
+
+// generateMarkdownReport creates the comprehensive human-readable report
+func (sec *SWEngineeringCollab) generateMarkdownReport(assessment map[string]map[string]interface{}) string {
 	report := fmt.Sprintf(`# SW Engineering Team Collaboration Report
 **Generated:** %s
 **Challenge:** %s
@@ -274,40 +910,73 @@ func (sec *SWEngineeringCollab) generateFinalReport(assessment map[string]map[st
 
 This report presents the results of a collaborative software engineering effort where %d team members worked on the challenge, conducted peer reviews, and consolidated feedback through a structured scoring system.
 
-## Team Performance Overview
+## Evaluation Rubric
 
 `, time.Now().Format("2006-01-02 15:04:05"), sec.Challenge, len(sec.Agents), len(sec.Agents))
 
+	for _, c := range sec.Rubric {
+		report += fmt.Sprintf("- **%s** (weight %.1f, 0-%d): %s\n", c.Name, c.Weight, c.MaxScore, c.Description)
+	}
+
+	report += "\n## Team Performance Overview\n\n"
+
 	// Team statistics
 	totalReviews := 0
 	totalScore := 0.0
-	for _, data := range assessment {
+	var contestedAgents []string
+	for name, data := range assessment {
 		if reviews, ok := data["review_count"].(int); ok {
 			totalReviews += reviews
 		}
 		if score, ok := data["avg_score"].(float64); ok {
 			totalScore += score
 		}
+		if contested, ok := data["contested"].(bool); ok && contested {
+			contestedAgents = append(contestedAgents, name)
+		}
 	}
 	avgTeamScore := totalScore / float64(len(sec.Agents))
+	sort.Strings(contestedAgents)
 
 	report += fmt.Sprintf(`- **Total Reviews Conducted:** %d
-- **Average Team Score:** %.1f/10
+- **Average Team Score (weighted by reviewer seniority):** %.1f/10
 - **Review Coverage:** %.1f reviews per team member
+- **Contested Work Items:** %d (weighted reviewer variance above %.1f)
 
 ## Individual Performance & Feedback
 
-`, totalReviews, avgTeamScore, float64(totalReviews)/float64(len(sec.Agents)))
+`, totalReviews, avgTeamScore, float64(totalReviews)/float64(len(sec.Agents)), len(contestedAgents), contestedVarianceThreshold)
 
 	// Individual assessments
 	for _, agent := range sec.Agents {
 		data := assessment[agent.Name]
 		report += fmt.Sprintf(`### %s (%s)
-**Average Peer Review Score:** %.1f/10 (%d reviews)
+**Weighted Consensus Score:** %.1f/10 ± %.1f (95%% CI, %d reviews, variance %.2f)
+`, agent.Name, agent.Role, data["avg_score"], data["ci_half_width"], data["review_count"], data["variance"])
 
-#### Key Strengths
-`, agent.Name, agent.Role, data["avg_score"], data["review_count"])
+		if contested, ok := data["contested"].(bool); ok && contested {
+			report += fmt.Sprintf("\n**CONTESTED:** reviewer scores disagree enough (variance %.2f > %.1f) that this consensus needs a tiebreaker round before it's trusted.\n", data["variance"], contestedVarianceThreshold)
+		}
+
+		report += "\n#### Review Weights\n"
+		if weights, ok := data["review_weights"].([]map[string]interface{}); ok {
+			for _, w := range weights {
+				report += fmt.Sprintf("- %s (weight %.1f): %d/10\n", w["reviewer"], w["weight"], w["score"])
+			}
+		}
+
+		report += "\n#### Rubric Breakdown\n"
+		if criterionScores, ok := data["criterion_scores"].(map[string]float64); ok {
+			for _, c := range sec.Rubric {
+				if score, ok := criterionScores[c.Name]; ok {
+					report += fmt.Sprintf("- %s: %.1f/%d\n", c.Name, score, c.MaxScore)
+				} else {
+					report += fmt.Sprintf("- %s: not scored\n", c.Name)
+				}
+			}
+		}
 
+		report += "\n#### Key Strengths\n"
 		if strengths, ok := data["strengths"].([]string); ok && len(strengths) > 0 {
 			for _, strength := range strengths {
 				report += fmt.Sprintf("- %s\n", strength)
@@ -346,6 +1015,8 @@ This report presents the results of a collaborative software engineering effort
 		report += "\n---\n\n"
 	}
 
+	report += sec.outlierAppendix(assessment)
+
 	report += `## Recommendations
 
 ### For Individual Contributors
@@ -376,9 +1047,179 @@ This collaborative effort demonstrates the value of structured peer review and t
 	return report
 }
 
-// saveReportToFile saves the final report to a timestamped file
+// reportDocument is the structured form of a consolidated assessment that
+// backs FormatJSON/FormatHTML, e.g. for a CI pipeline that wants to gate on
+// AverageScore without parsing prose.
+type reportDocument struct {
+	Generated     string             `json:"generated"`
+	Challenge     string             `json:"challenge"`
+	TeamSize      int                `json:"team_size"`
+	Rubric        Rubric             `json:"rubric"`
+	AverageScore  float64            `json:"average_score"`
+	ContestedOnes []string           `json:"contested_agents"`
+	Agents        []agentReportEntry `json:"agents"`
+}
+
+// agentReportEntry is one team member's consolidated assessment within a
+// reportDocument.
+type agentReportEntry struct {
+	Name            string             `json:"name"`
+	Role            string             `json:"role"`
+	Score           float64            `json:"score"`
+	CIHalfWidth     float64            `json:"ci_half_width"`
+	Variance        float64            `json:"variance"`
+	Contested       bool               `json:"contested"`
+	CriterionScores map[string]float64 `json:"criterion_scores"`
+	Strengths       []string           `json:"strengths"`
+	Weaknesses      []string           `json:"weaknesses"`
+	Suggestions     []string           `json:"suggestions"`
+	Outliers        []Review           `json:"outliers"`
+}
+
+// buildReportDocument flattens assessment (and sec's rubric/challenge) into
+// the shape generateJSONReport/generateHTMLReport render.
+func (sec *SWEngineeringCollab) buildReportDocument(assessment map[string]map[string]interface{}) reportDocument {
+	doc := reportDocument{
+		Generated: time.Now().Format("2006-01-02 15:04:05"),
+		Challenge: sec.Challenge,
+		TeamSize:  len(sec.Agents),
+		Rubric:    sec.Rubric,
+	}
+
+	totalScore := 0.0
+	for _, agent := range sec.Agents {
+		data := assessment[agent.Name]
+		if data == nil {
+			continue
+		}
+
+		score, _ := data["avg_score"].(float64)
+		ciHalfWidth, _ := data["ci_half_width"].(float64)
+		variance, _ := data["variance"].(float64)
+		contested, _ := data["contested"].(bool)
+		criterionScores, _ := data["criterion_scores"].(map[string]float64)
+		strengths, _ := data["strengths"].([]string)
+		weaknesses, _ := data["weaknesses"].([]string)
+		suggestions, _ := data["suggestions"].([]string)
+		outliers, _ := data["outliers"].([]Review)
+
+		totalScore += score
+		if contested {
+			doc.ContestedOnes = append(doc.ContestedOnes, agent.Name)
+		}
+
+		doc.Agents = append(doc.Agents, agentReportEntry{
+			Name:            agent.Name,
+			Role:            agent.Role,
+			Score:           score,
+			CIHalfWidth:     ciHalfWidth,
+			Variance:        variance,
+			Contested:       contested,
+			CriterionScores: criterionScores,
+			Strengths:       strengths,
+			Weaknesses:      weaknesses,
+			Suggestions:     suggestions,
+			Outliers:        outliers,
+		})
+	}
+
+	if len(sec.Agents) > 0 {
+		doc.AverageScore = totalScore / float64(len(sec.Agents))
+	}
+	return doc
+}
+
+// generateJSONReport renders assessment as indented JSON, for pipelines
+// that want to gate CI on AverageScore rather than read prose.
+func (sec *SWEngineeringCollab) generateJSONReport(assessment map[string]map[string]interface{}) string {
+	out, err := json.MarshalIndent(sec.buildReportDocument(assessment), "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(out)
+}
+
+// generateHTMLReport renders assessment as a standalone HTML page covering
+// the same information as generateMarkdownReport.
+func (sec *SWEngineeringCollab) generateHTMLReport(assessment map[string]map[string]interface{}) string {
+	doc := sec.buildReportDocument(assessment)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>SW Engineering Team Collaboration Report</title></head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>SW Engineering Team Collaboration Report</h1>\n")
+	fmt.Fprintf(&b, "<p><strong>Generated:</strong> %s<br><strong>Challenge:</strong> %s<br><strong>Team Size:</strong> %d engineers<br><strong>Average Score:</strong> %.1f/10</p>\n",
+		doc.Generated, doc.Challenge, doc.TeamSize, doc.AverageScore)
+
+	fmt.Fprintf(&b, "<h2>Evaluation Rubric</h2>\n<ul>\n")
+	for _, c := range doc.Rubric {
+		fmt.Fprintf(&b, "<li><strong>%s</strong> (weight %.1f, 0-%d): %s</li>\n", c.Name, c.Weight, c.MaxScore, c.Description)
+	}
+	fmt.Fprintf(&b, "</ul>\n")
+
+	fmt.Fprintf(&b, "<h2>Individual Performance</h2>\n")
+	for _, agent := range doc.Agents {
+		fmt.Fprintf(&b, "<h3>%s (%s)</h3>\n", agent.Name, agent.Role)
+		fmt.Fprintf(&b, "<p>Weighted Consensus Score: %.1f/10 &plusmn; %.1f (95%% CI, variance %.2f)%s</p>\n",
+			agent.Score, agent.CIHalfWidth, agent.Variance, contestedBadge(agent.Contested))
+
+		fmt.Fprintf(&b, "<h4>Rubric Breakdown</h4>\n<ul>\n")
+		for _, c := range doc.Rubric {
+			if score, ok := agent.CriterionScores[c.Name]; ok {
+				fmt.Fprintf(&b, "<li>%s: %.1f/%d</li>\n", c.Name, score, c.MaxScore)
+			} else {
+				fmt.Fprintf(&b, "<li>%s: not scored</li>\n", c.Name)
+			}
+		}
+		fmt.Fprintf(&b, "</ul>\n")
+
+		fmt.Fprintf(&b, "<h4>Strengths</h4>\n<ul>\n")
+		for _, s := range agent.Strengths {
+			fmt.Fprintf(&b, "<li>%s</li>\n", s)
+		}
+		fmt.Fprintf(&b, "</ul>\n<h4>Areas for Improvement</h4>\n<ul>\n")
+		for _, w := range agent.Weaknesses {
+			fmt.Fprintf(&b, "<li>%s</li>\n", w)
+		}
+		fmt.Fprintf(&b, "</ul>\n<h4>Suggested Improvements</h4>\n<ul>\n")
+		for _, sug := range agent.Suggestions {
+			fmt.Fprintf(&b, "<li>%s</li>\n", sug)
+		}
+		fmt.Fprintf(&b, "</ul>\n")
+	}
+
+	fmt.Fprintf(&b, "<h2>Outlier Reviews (Possible Reviewer Bias)</h2>\n<ul>\n")
+	anyOutliers := false
+	for _, agent := range doc.Agents {
+		for _, o := range agent.Outliers {
+			anyOutliers = true
+			fmt.Fprintf(&b, "<li>%s &rarr; %s: scored %d/10 (more than ~2&sigma; from the %.1f/10 consensus)</li>\n", o.Reviewer, agent.Name, o.Score, agent.Score)
+		}
+	}
+	if !anyOutliers {
+		fmt.Fprintf(&b, "<li>No reviews were flagged as statistical outliers.</li>\n")
+	}
+	fmt.Fprintf(&b, "</ul>\n")
+
+	fmt.Fprintf(&b, "</body>\n</html>\n")
+	return b.String()
+}
+
+// contestedBadge renders the HTML report's inline "needs a tiebreaker"
+// callout for a contested agent, or "" otherwise.
+func contestedBadge(contested bool) string {
+	if !contested {
+		return ""
+	}
+	return " — <strong>CONTESTED</strong>: needs a tiebreaker round"
+}
+
+// saveReportToFile saves the final report into this run's workspace
+// directory, alongside any artifacts extracted by the PostStep pipeline.
 func (sec *SWEngineeringCollab) saveReportToFile(report string) error {
-	filename := fmt.Sprintf("examples/sw_team_report_%s.md", time.Now().Format("2006-01-02_15-04-05"))
+	if err := os.MkdirAll(sec.workspaceDir(), 0755); err != nil {
+		return err
+	}
+	filename := filepath.Join(sec.workspaceDir(), fmt.Sprintf("sw_team_report_%s.%s", sec.runID, sec.Format.extension()))
 	return os.WriteFile(filename, []byte(report), 0644)
 }
 
@@ -466,22 +1307,24 @@ Provide a complete implementation plan including:
 - Security measures
 - Testing approach`
 
-	// Create and run collaboration
-	collab := NewSWEngineeringCollab(agents, challenge)
-
-	// Custom progress callback
-	collab.OnProgress = func(current, total int, phase string) {
-		percentage := float64(current) / float64(total) * 100
-		barWidth := 50
-		filled := int(percentage / 100 * float64(barWidth))
-
-		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-
-		fmt.Printf("\r[%s] %.1f%% (%d/%d) %s", bar, percentage, current, total, phase)
-		if current == total {
-			fmt.Println() // New line when complete
-		}
-	}
+	// Create and run collaboration, reviewing up to 3 pairs at a time
+	collab := NewSWEngineeringCollab(challenge,
+		WithAgents(agents),
+		WithConcurrency(3),
+		WithReviewTimeout(2*time.Minute),
+		WithProgress(func(current, total int, phase string) {
+			percentage := float64(current) / float64(total) * 100
+			barWidth := 50
+			filled := int(percentage / 100 * float64(barWidth))
+
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+			fmt.Printf("\r[%s] %.1f%% (%d/%d) %s", bar, percentage, current, total, phase)
+			if current == total {
+				fmt.Println() // New line when complete
+			}
+		}),
+	)
 
 	// Run the collaboration
 	err := collab.Run()
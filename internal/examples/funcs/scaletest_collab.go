@@ -0,0 +1,62 @@
+package funcs
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	breeze "github.com/user/breeze"
+	"github.com/user/breeze/scaletest"
+)
+
+// RunScaletestCollab load-tests a single built-in CollaborationMethod
+// against scaletest.MockAI (so the numbers measure breeze's own
+// orchestration overhead - goroutine fan-out, Parallel's semaphore,
+// SharedKnowledge locking - not a real model's latency), optionally serving
+// live Prometheus metrics while it runs. Flags (parsed from os.Args[2:],
+// since the registry's Run signature takes none):
+//
+//	go run examples/main.go scaletest_collab -method parallel -method-param 8 \
+//	  -agents 5 -duration 30s -concurrency 20 -prometheus-address :9090
+func RunScaletestCollab() {
+	fs := flag.NewFlagSet("scaletest_collab", flag.ExitOnError)
+	method := fs.String("method", "parallel", "CollaborationMethod: sequential, parallel, peer_review, consensus, debate")
+	methodParam := fs.Int("method-param", 4, "maxConcurrency (parallel/peer_review/consensus) or rounds (debate)")
+	agents := fs.Int("agents", 5, "number of synthetic agents")
+	duration := fs.String("duration", "10s", "how long to run, e.g. 30s")
+	concurrency := fs.Int("concurrency", 10, "concurrent Collaboration.Run workers")
+	output := fs.String("output", "text", "report format: text, json, csv")
+	promAddress := fs.String("prometheus-address", "", "if set, serve live metrics at this address (e.g. :9090) for the run's duration")
+	mockLatency := fs.Duration("mock-latency", 50*time.Millisecond, "simulated MockAI Generate latency")
+	mockJitter := fs.Duration("mock-jitter", 20*time.Millisecond, "simulated MockAI Generate latency jitter")
+	fs.Parse(os.Args[2:])
+
+	breeze.SetDefaultProvider(scaletest.MockAI{Latency: *mockLatency, Jitter: *mockJitter})
+
+	runner := scaletest.NewCollabRunner(scaletest.CollabSpec{
+		Method:      *method,
+		MethodParam: *methodParam,
+		Agents:      *agents,
+		Input:       "Design and summarize a caching layer for a high-traffic API.",
+		Duration:    *duration,
+		Concurrency: *concurrency,
+		Output:      *output,
+	})
+
+	if *promAddress != "" {
+		handler := scaletest.NewMetricsHandler()
+		runner.Metrics = handler
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", handler)
+		server := &http.Server{Addr: *promAddress, Handler: mux}
+		go server.ListenAndServe()
+		defer server.Close()
+		fmt.Printf("Serving Prometheus metrics at http://%s/metrics while the test runs\n", *promAddress)
+	}
+
+	if _, err := runner.Run(); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
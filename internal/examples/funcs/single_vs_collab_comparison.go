@@ -1,10 +1,12 @@
 package funcs
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	breeze "github.com/user/breeze"
+	"github.com/user/breeze/analyzer"
 )
 
 // RunSingleVsCollabComparison compares single LLM vs collaborative approach on symbolic integration
@@ -76,4 +78,57 @@ Show all steps, use integration by parts and substitution as needed.`
 	fmt.Println("=== COMPARISON SUMMARY ===")
 	fmt.Println("Single LLM: Likely to provide a basic solution, may skip steps or make errors")
 	fmt.Println("Collaborative: Structured approach, peer review, detailed steps, higher accuracy")
-}
\ No newline at end of file
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("=== STATISTICAL COMPARISON (analyzer) ===")
+	runStatisticalComparison(agents, phases)
+}
+
+// runStatisticalComparison treats "single LLM" and "collaborative" as the
+// control/treatment arms of an analyzer.Experiment over a small prompt
+// corpus, scoring each arm's output with an LLM judge (cached, so a repeat
+// run doesn't re-invoke the judge for output it has already scored) and
+// reporting a Mann-Whitney U test plus Cliff's delta between arms - the
+// same agents/phases used above, just run as a repeated, scored experiment
+// instead of a single side-by-side print.
+func runStatisticalComparison(agents []breeze.Agent, phases []breeze.Phase) {
+	corpus := []string{
+		`Compute the indefinite integral of x^2 * cos(x) dx.`,
+		`Compute the indefinite integral of (2x+1) / (x^2+x+1) dx.`,
+	}
+
+	judge := analyzer.NewCachingJudge(analyzer.LLMJudge{Rubric: "mathematical correctness and clarity of the worked steps"})
+
+	exp := &analyzer.Experiment{
+		Arms: []analyzer.Arm{
+			{
+				Name: "single_llm",
+				Run: func(ctx context.Context, prompt string) (string, error) {
+					return breeze.AI(prompt, breeze.WithCtx(ctx)), nil
+				},
+			},
+			{
+				Name: "collaborative",
+				Run: func(ctx context.Context, prompt string) (string, error) {
+					collab := breeze.NewCollaboration(agents, phases)
+					results, err := collab.Run(prompt)
+					if err != nil {
+						return "", err
+					}
+					return results["Assemble Final Answer"]["Assembler"], nil
+				},
+			},
+		},
+		Prompts:    corpus,
+		Replicates: 1,
+		Judge:      judge,
+		Paired:     true,
+	}
+
+	report, err := exp.Run(context.Background())
+	if err != nil {
+		fmt.Println("Experiment failed:", err)
+		return
+	}
+	fmt.Println(analyzer.RenderRanking(report))
+}
@@ -47,10 +47,14 @@ Show all steps, use integration by parts and substitution as needed. Each agent
 			Name:           "Assemble Final Answer",
 			Description:    "Assemble the final answer, check correctness, and format the solution in clear LaTeX.",
 			PromptTemplate: "You are the assembler. Assemble the final answer, check correctness, and format the solution in clear LaTeX.",
+			Verifier: breeze.SymPyIntegralVerifier{
+				Integrand: "x**4*sin(x)/(1+x**2)**2",
+			},
 		},
 	}
 
 	collab := breeze.NewCollaboration(agents, phases)
+	collab.MaxRepairRounds = 2
 	results, err := collab.Run(problem)
 	if err != nil {
 		fmt.Println("Error:", err)
@@ -0,0 +1,174 @@
+package breeze
+
+import (
+	"sync"
+	"time"
+)
+
+// OutcomeStatus classifies why an agent's turn ended the way it did, rather
+// than collapsing every non-happy-path into a single opaque error. Naming
+// borrows from the taxonomy used by automated theorem-proving frontends,
+// since "the model didn't answer" has just as many shapes as "the proof
+// didn't go through".
+type OutcomeStatus int
+
+const (
+	// StatusOK means the agent produced a usable response (and, if a
+	// Phase.Verifier was set, it was Verified or no verifier ran).
+	StatusOK OutcomeStatus = iota
+	// StatusMaybeUnprovable means a verifier ran out of repair rounds while
+	// still Refuted or Inconclusive.
+	StatusMaybeUnprovable
+	// StatusGaveUp means the agent explicitly declined to answer.
+	StatusGaveUp
+	// StatusTimedOut means the request was canceled by a deadline.
+	StatusTimedOut
+	// StatusMalformedOutput means the response could not be parsed into the
+	// shape the caller expected (e.g. a structured review).
+	StatusMalformedOutput
+	// StatusInterrupted means the run was canceled externally.
+	StatusInterrupted
+	// StatusUnknownError covers any other failure, such as a transport error
+	// talking to the backend.
+	StatusUnknownError
+)
+
+func (s OutcomeStatus) String() string {
+	switch s {
+	case StatusOK:
+		return "OK"
+	case StatusMaybeUnprovable:
+		return "MaybeUnprovable"
+	case StatusGaveUp:
+		return "GaveUp"
+	case StatusTimedOut:
+		return "TimedOut"
+	case StatusMalformedOutput:
+		return "MalformedOutput"
+	case StatusInterrupted:
+		return "Interrupted"
+	default:
+		return "UnknownError"
+	}
+}
+
+// AgentOutcome is the structured result of a single agent's turn within a
+// phase, replacing a bare response string so callers can tell a refusal
+// apart from a verifier-rejected answer apart from a clean success.
+type AgentOutcome struct {
+	Text       string
+	Status     OutcomeStatus
+	Reason     string
+	Retries    int
+	DurationMs int64
+}
+
+// PhaseOutcome maps agent name to that agent's outcome for one phase.
+type PhaseOutcome map[string]AgentOutcome
+
+// RunWithOutcomes drives the exact same Phase.Condition/Agent.When/Router/
+// ConversationID machinery as Run (see runCollaborationLoop), but returns
+// structured AgentOutcomes instead of bare strings so callers can
+// distinguish a verifier-rejected answer, a timeout, or an error from a
+// clean success. A phase.Method handles its own verification internally
+// (see policyAI) and returns only text, so an agent driven through one
+// always reports StatusOK; Status/Reason/Retries are only populated for the
+// built-in sequential/parallel dispatch, via verifyAndRepairTracked.
+func (c *Collaboration) RunWithOutcomes(initialPrompt string) (map[string]PhaseOutcome, error) {
+	outcomes := make(map[string]PhaseOutcome)
+
+	_, err := c.runCollaborationLoop(initialPrompt, func(phase Phase) map[string]string {
+		phaseOutcomes, texts := c.runPhaseOutcomes(phase, initialPrompt)
+		outcomes[phase.Name] = phaseOutcomes
+		return texts
+	})
+
+	return outcomes, err
+}
+
+// runPhaseOutcomes executes a single phase the same way runPhase does -
+// honoring phase.Method, IsParallel, Agent.When, tool access (via
+// runAgentTurnWithTools), and PostStep - while also recording a
+// PhaseOutcome per agent.
+func (c *Collaboration) runPhaseOutcomes(phase Phase, initialPrompt string) (PhaseOutcome, map[string]string) {
+	outcomes := make(PhaseOutcome)
+	texts := make(map[string]string)
+
+	if phase.Method != nil {
+		responses := phase.Method(c.participatingAgents(phase), c, phase, initialPrompt)
+		for name, text := range responses {
+			outcomes[name] = AgentOutcome{Text: text, Status: StatusOK}
+			texts[name] = text
+		}
+		return outcomes, texts
+	}
+
+	runAndRecord := func(agent Agent, record func(AgentOutcome)) {
+		start := time.Now()
+		prompt := c.BuildAgentPrompt(agent, phase, initialPrompt)
+		response := c.runAgentTurnWithTools(agent, prompt, phase.Attachments...)
+		response, retries, verification := c.verifyAndRepairTracked(agent, phase, initialPrompt, response)
+		response = c.runPostStep(agent, phase, response)
+
+		outcome := AgentOutcome{
+			Text:       response,
+			Status:     StatusOK,
+			Retries:    retries,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if verification != nil && verification.Status != Verified {
+			outcome.Status = StatusMaybeUnprovable
+			outcome.Reason = verification.Detail
+		}
+		record(outcome)
+
+		if c.OnAgentResponse != nil {
+			c.OnAgentResponse(agent.Name, outcome.Text)
+		}
+	}
+
+	if phase.IsParallel {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+
+		maxConcurrency := phase.MaxConcurrency
+		if maxConcurrency <= 0 {
+			maxConcurrency = len(c.Agents)
+		}
+		semaphore := make(chan struct{}, maxConcurrency)
+
+		for _, agent := range c.Agents {
+			if !c.agentParticipates(agent, phase) {
+				continue
+			}
+
+			wg.Add(1)
+			go func(agent Agent) {
+				defer wg.Done()
+
+				semaphore <- struct{}{}        // Acquire
+				defer func() { <-semaphore }() // Release
+
+				runAndRecord(agent, func(outcome AgentOutcome) {
+					mu.Lock()
+					outcomes[agent.Name] = outcome
+					texts[agent.Name] = outcome.Text
+					mu.Unlock()
+				})
+			}(agent)
+		}
+		wg.Wait()
+		return outcomes, texts
+	}
+
+	for _, agent := range c.Agents {
+		if !c.agentParticipates(agent, phase) {
+			continue
+		}
+		runAndRecord(agent, func(outcome AgentOutcome) {
+			outcomes[agent.Name] = outcome
+			texts[agent.Name] = outcome.Text
+		})
+	}
+	return outcomes, texts
+}
@@ -0,0 +1,120 @@
+package breeze
+
+import (
+	"testing"
+)
+
+// alwaysRefuteVerifier is a Verifier stub that never accepts the answer, so
+// verifyAndRepairTracked exhausts MaxRepairRounds and reports a non-Verified
+// status.
+type alwaysRefuteVerifier struct{}
+
+func (alwaysRefuteVerifier) Verify(_, _ string) VerificationResult {
+	return VerificationResult{Status: Refuted, Detail: "never satisfied"}
+}
+
+// TestRunWithOutcomes_HonorsConditionAndRouter checks that RunWithOutcomes
+// skips a Phase.Condition-false phase and follows Collaboration.Router,
+// exactly like Run, since both now share runCollaborationLoop.
+func TestRunWithOutcomes_HonorsConditionAndRouter(t *testing.T) {
+	agent := Agent{Name: "Alice", Backend: &fakeMethodBackend{Reply: "ok"}}
+	collab := &Collaboration{
+		Agents:          []Agent{agent},
+		SharedKnowledge: make(map[string]string),
+		Router:          `'third'`,
+		Phases: []Phase{
+			{Name: "first", Method: Sequential()},
+			{Name: "second", Condition: "false", Method: Sequential()},
+			{Name: "third", Method: Sequential()},
+		},
+	}
+
+	outcomes, err := collab.RunWithOutcomes("do the thing")
+	if err != nil {
+		t.Fatalf("RunWithOutcomes failed: %v", err)
+	}
+	if _, ran := outcomes["second"]; ran {
+		t.Errorf("Expected Router to skip straight past \"second\", got outcomes for it: %+v", outcomes["second"])
+	}
+	if _, ran := outcomes["third"]; !ran {
+		t.Errorf("Expected Router to route from \"first\" to \"third\", got outcomes: %+v", outcomes)
+	}
+}
+
+// TestRunWithOutcomes_DispatchesPhaseMethod checks that a Method-driven
+// phase is still run through phase.Method (not bypassed), and reports
+// StatusOK since policyAI doesn't expose retry/verification detail back to
+// RunWithOutcomes.
+func TestRunWithOutcomes_DispatchesPhaseMethod(t *testing.T) {
+	backend := &fakeMethodBackend{Reply: "draft answer"}
+	agent := Agent{Name: "Alice", Backend: backend}
+	collab := &Collaboration{
+		Agents:          []Agent{agent},
+		SharedKnowledge: make(map[string]string),
+		Phases:          []Phase{{Name: "Design", Method: Sequential()}},
+	}
+
+	outcomes, err := collab.RunWithOutcomes("design the widget")
+	if err != nil {
+		t.Fatalf("RunWithOutcomes failed: %v", err)
+	}
+	if len(backend.prompts) != 1 {
+		t.Fatalf("Expected phase.Method to invoke the agent's Backend once, got %d calls", len(backend.prompts))
+	}
+	outcome := outcomes["Design"]["Alice"]
+	if outcome.Status != StatusOK || outcome.Text != "draft answer" {
+		t.Errorf("Expected StatusOK with the backend's reply, got %+v", outcome)
+	}
+}
+
+// TestRunWithOutcomes_ExcludesAgentsThatSitOut checks that Agent.When
+// filtering, not just Phase.Condition, is honored - an agent that sits out a
+// phase should not appear in that phase's PhaseOutcome at all.
+func TestRunWithOutcomes_ExcludesAgentsThatSitOut(t *testing.T) {
+	inAgent := Agent{Name: "In", Backend: &fakeMethodBackend{Reply: "ok"}}
+	outAgent := Agent{Name: "Out", When: "false", Backend: &fakeMethodBackend{Reply: "ok"}}
+	collab := &Collaboration{
+		Agents:          []Agent{inAgent, outAgent},
+		SharedKnowledge: make(map[string]string),
+		Phases:          []Phase{{Name: "Review"}},
+	}
+
+	outcomes, err := collab.RunWithOutcomes("review this")
+	if err != nil {
+		t.Fatalf("RunWithOutcomes failed: %v", err)
+	}
+	if _, ok := outcomes["Review"]["In"]; !ok {
+		t.Errorf("Expected \"In\" to have an outcome, got %+v", outcomes["Review"])
+	}
+	if _, ok := outcomes["Review"]["Out"]; ok {
+		t.Errorf("Expected \"Out\" to sit out the phase (Agent.When == false), got an outcome for it: %+v", outcomes["Review"]["Out"])
+	}
+}
+
+// TestRunWithOutcomes_VerifierFailureReportsMaybeUnprovable checks that a
+// Verifier that never accepts the answer surfaces as StatusMaybeUnprovable
+// with Retries/Reason populated, via verifyAndRepairTracked.
+func TestRunWithOutcomes_VerifierFailureReportsMaybeUnprovable(t *testing.T) {
+	agent := Agent{Name: "Alice", Backend: &fakeMethodBackend{Reply: "42"}}
+	collab := &Collaboration{
+		Agents:          []Agent{agent},
+		SharedKnowledge: make(map[string]string),
+		MaxRepairRounds: 2,
+		Phases:          []Phase{{Name: "Prove", Verifier: alwaysRefuteVerifier{}}},
+	}
+
+	outcomes, err := collab.RunWithOutcomes("prove it")
+	if err != nil {
+		t.Fatalf("RunWithOutcomes failed: %v", err)
+	}
+	outcome := outcomes["Prove"]["Alice"]
+	if outcome.Status != StatusMaybeUnprovable {
+		t.Errorf("Expected StatusMaybeUnprovable after an always-refuting Verifier, got %+v", outcome)
+	}
+	if outcome.Retries != collab.MaxRepairRounds {
+		t.Errorf("Expected Retries == MaxRepairRounds (%d), got %d", collab.MaxRepairRounds, outcome.Retries)
+	}
+	if outcome.Reason == "" {
+		t.Errorf("Expected Reason to carry the verifier's Detail, got empty")
+	}
+}
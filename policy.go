@@ -0,0 +1,395 @@
+package breeze
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PolicyAction names the kind of operation a PolicyRequest describes - a
+// CollaborationMethod reading shared context, writing its response into
+// SharedKnowledge, or publishing a final output file.
+type PolicyAction string
+
+const (
+	PolicyRead    PolicyAction = "read"
+	PolicyWrite   PolicyAction = "write"
+	PolicyPublish PolicyAction = "publish"
+)
+
+// PolicyEffect is the outcome a PolicyRule produces for a matching request.
+type PolicyEffect int
+
+const (
+	// PolicyAllow lets the call through unchanged.
+	PolicyAllow PolicyEffect = iota
+	// PolicyDeny blocks the call; the prompt is replaced with PolicyEngine's
+	// deny message (for a pre-call request) or the response is discarded
+	// (for a post-call request).
+	PolicyDeny
+	// PolicyTransform lets the call through but runs the rule's Transform
+	// first, e.g. to redact secrets from a prompt or truncate a response.
+	PolicyTransform
+)
+
+func (e PolicyEffect) String() string {
+	switch e {
+	case PolicyAllow:
+		return "allow"
+	case PolicyDeny:
+		return "deny"
+	case PolicyTransform:
+		return "transform"
+	default:
+		return "unknown"
+	}
+}
+
+// PolicyRequest is the (Subject, Object, Action) triple a CollaborationMethod
+// asks a PolicyEngine to evaluate - Subject is the calling agent, Object is
+// the phase name, SharedKnowledge key, or output file being touched, and
+// Action is what's being done to it.
+type PolicyRequest struct {
+	Agent  Agent
+	Phase  Phase
+	Object string
+	Action PolicyAction
+	// Text is the prompt (for a pre-call request) or response (for a
+	// post-call request) a PolicyTransform rule may rewrite.
+	Text string
+}
+
+// PolicyDecision is the audit record a PolicyEngine produces for every
+// PolicyRequest it evaluates, surfaced via Collaboration.OnPolicyDecision.
+type PolicyDecision struct {
+	Request PolicyRequest
+	Effect  PolicyEffect
+	// Rule is the name of the PolicyRule that decided this request, or ""
+	// if no rule matched (the engine's default-allow fell through).
+	Rule string
+	// Text is Request.Text after PolicyTransform has run, unchanged for
+	// PolicyAllow/PolicyDeny.
+	Text string
+}
+
+// PolicyRule is one entry in a PolicyEngine's bundle: a named predicate over
+// a PolicyRequest, evaluated either as the small embedded DSL described by
+// ParsePolicyBundle (Clauses) or, for rule shapes the DSL can't express
+// (cross-field comparisons like "object == agent.name"), a Predicate
+// supplied directly in Go - see DefaultPolicyBundle. Effect says what
+// happens on a match; Transform is a rewrite func registered separately
+// since it can't be expressed as DSL text.
+type PolicyRule struct {
+	Name      string
+	Effect    PolicyEffect
+	Clauses   []policyClause
+	Predicate func(PolicyRequest) bool
+	Transform TransformFunc
+}
+
+// TransformFunc rewrites a PolicyRequest's Text for a PolicyTransform rule,
+// e.g. redacting secrets from a prompt or truncating a response.
+type TransformFunc func(PolicyRequest) string
+
+// Matches reports whether r applies to req: its Predicate if set, otherwise
+// an AND across Clauses (mirroring a Rego rule body's comma/newline-
+// separated terms); a rule with neither always matches.
+func (r PolicyRule) Matches(req PolicyRequest) bool {
+	if r.Predicate != nil {
+		return r.Predicate(req)
+	}
+	for _, c := range r.Clauses {
+		if !c.holds(req) {
+			return false
+		}
+	}
+	return true
+}
+
+// PolicyEngine evaluates PolicyRequests against an ordered bundle of rules,
+// first match wins; no match falls through to PolicyAllow. Rules is
+// guarded by mu so WatchBundle can hot-reload a running engine.
+type PolicyEngine struct {
+	mu    sync.RWMutex
+	rules []PolicyRule
+}
+
+// NewPolicyEngine returns a PolicyEngine evaluating rules in order.
+func NewPolicyEngine(rules ...PolicyRule) *PolicyEngine {
+	return &PolicyEngine{rules: rules}
+}
+
+// SetRules atomically replaces the engine's rule set, used by LoadBundle and
+// WatchBundle to hot-reload without racing an in-flight Evaluate.
+func (e *PolicyEngine) SetRules(rules []PolicyRule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = rules
+}
+
+// Evaluate finds the first rule matching req and returns the PolicyDecision
+// it produces; an empty bundle (or no match) is PolicyAllow with req.Text
+// unchanged.
+func (e *PolicyEngine) Evaluate(req PolicyRequest) PolicyDecision {
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+
+	for _, rule := range rules {
+		if !rule.Matches(req) {
+			continue
+		}
+		text := req.Text
+		if rule.Effect == PolicyTransform && rule.Transform != nil {
+			text = rule.Transform(req)
+		}
+		return PolicyDecision{Request: req, Effect: rule.Effect, Rule: rule.Name, Text: text}
+	}
+	return PolicyDecision{Request: req, Effect: PolicyAllow, Text: req.Text}
+}
+
+// policyClause is one "input.x.y == \"value\"" (or !=) term of a rule body.
+type policyClause struct {
+	path string // e.g. "agent.role", "action", "object"
+	neg  bool
+	want string
+}
+
+func (c policyClause) holds(req PolicyRequest) bool {
+	got, ok := policyField(req, c.path)
+	if !ok {
+		return false
+	}
+	eq := got == c.want
+	if c.neg {
+		return !eq
+	}
+	return eq
+}
+
+// policyField resolves an "input.<path>" reference against req - the small,
+// fixed set of fields the DSL can reach (agent.name, agent.role, phase.name,
+// object, action); an unrecognized path never matches.
+func policyField(req PolicyRequest, path string) (string, bool) {
+	switch path {
+	case "agent.name":
+		return req.Agent.Name, true
+	case "agent.role":
+		return req.Agent.Role, true
+	case "phase.name":
+		return req.Phase.Name, true
+	case "object":
+		return req.Object, true
+	case "action":
+		return string(req.Action), true
+	default:
+		return "", false
+	}
+}
+
+// ruleHeadPattern matches a rule's head line, e.g. `allow {` or `deny some_name {`.
+var ruleHeadPattern = regexp.MustCompile(`^(allow|deny|transform)\s*([A-Za-z0-9_]*)\s*\{\s*$`)
+
+// clausePattern matches one clause line, e.g. `input.agent.role == "QA Engineer"`.
+var clausePattern = regexp.MustCompile(`^input\.([A-Za-z0-9_.]+)\s*(==|!=)\s*"([^"]*)"$`)
+
+// ParsePolicyBundle parses a small Rego-style DSL: one rule per
+// `allow|deny|transform [name] { ... }` block, each body line an
+// `input.<path> (==|!=) "<value>"` clause ANDed together (blank lines and
+// lines starting with # are ignored). A `transform` rule's Transform func
+// isn't carried by the text format - set rules[i].Transform on the parsed
+// result before handing it to NewPolicyEngine, or leave it nil to pass the
+// text through unchanged.
+func ParsePolicyBundle(src string) ([]PolicyRule, error) {
+	var rules []PolicyRule
+	var current *PolicyRule
+
+	for lineNo, raw := range strings.Split(src, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "}" {
+			if current == nil {
+				return nil, fmt.Errorf("policy bundle line %d: unexpected %q with no open rule", lineNo+1, line)
+			}
+			rules = append(rules, *current)
+			current = nil
+			continue
+		}
+		if current == nil {
+			m := ruleHeadPattern.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("policy bundle line %d: expected a rule head, got %q", lineNo+1, line)
+			}
+			effect := PolicyAllow
+			switch m[1] {
+			case "deny":
+				effect = PolicyDeny
+			case "transform":
+				effect = PolicyTransform
+			}
+			name := m[2]
+			if name == "" {
+				name = fmt.Sprintf("%s_%d", m[1], len(rules)+1)
+			}
+			current = &PolicyRule{Name: name, Effect: effect}
+			continue
+		}
+
+		clause, err := parsePolicyClause(strings.TrimSuffix(line, ";"))
+		if err != nil {
+			return nil, fmt.Errorf("policy bundle line %d: %w", lineNo+1, err)
+		}
+		current.Clauses = append(current.Clauses, clause)
+	}
+	if current != nil {
+		return nil, fmt.Errorf("policy bundle: rule %q is missing its closing %q", current.Name, "}")
+	}
+	return rules, nil
+}
+
+func parsePolicyClause(line string) (policyClause, error) {
+	m := clausePattern.FindStringSubmatch(line)
+	if m == nil {
+		return policyClause{}, fmt.Errorf("invalid clause %q (want input.path == \"value\")", line)
+	}
+	return policyClause{path: m[1], neg: m[2] == "!=", want: m[3]}, nil
+}
+
+// LoadPolicyBundle reads every *.rego file in dir (sorted by name, so bundle
+// order is deterministic), parses each with ParsePolicyBundle, and returns
+// the concatenated rule list.
+func LoadPolicyBundle(dir string) ([]PolicyRule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load policy bundle: read %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".rego") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var rules []PolicyRule
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("load policy bundle: read %s: %w", name, err)
+		}
+		parsed, err := ParsePolicyBundle(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("load policy bundle: %s: %w", name, err)
+		}
+		rules = append(rules, parsed...)
+	}
+	return rules, nil
+}
+
+// WatchBundle polls dir every interval and hot-reloads e's rules via
+// LoadPolicyBundle whenever any *.rego file's mtime changes, logging (not
+// returning) a reload error so a transient bad edit doesn't tear down an
+// otherwise-healthy run. Call the returned stop func to end the watch.
+func (e *PolicyEngine) WatchBundle(dir string, interval time.Duration) (stop func(), err error) {
+	lastMod, err := bundleModTime(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				modTime, err := bundleModTime(dir)
+				if err != nil || !modTime.After(lastMod) {
+					continue
+				}
+				rules, err := LoadPolicyBundle(dir)
+				if err != nil {
+					fmt.Printf("⚠️  policy bundle reload of %s failed: %v\n", dir, err)
+					continue
+				}
+				e.SetRules(rules)
+				lastMod = modTime
+			}
+		}
+	}()
+
+	return func() { close(done) }, nil
+}
+
+// bundleModTime returns the newest mtime among dir's *.rego files.
+func bundleModTime(dir string) (time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("stat policy bundle %s: %w", dir, err)
+	}
+	var newest time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".rego") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest, nil
+}
+
+// DefaultPolicyBundle returns the out-of-the-box rule set: deny an agent
+// writing to a SharedKnowledge key that isn't its own name and isn't listed
+// in its phase's Produces, so examples like RunWebAppTruckSimulation can run
+// unattended without one agent clobbering another's contribution.
+func DefaultPolicyBundle() *PolicyEngine {
+	return NewPolicyEngine(PolicyRule{
+		Name:   "protect_shared_knowledge",
+		Effect: PolicyDeny,
+		Predicate: func(req PolicyRequest) bool {
+			if req.Action != PolicyWrite || req.Object == req.Agent.Name {
+				return false
+			}
+			for _, produced := range req.Phase.Produces {
+				if produced == req.Object {
+					return false
+				}
+			}
+			return true
+		},
+	})
+}
+
+// checkPolicy runs req through collab.Policy (a no-op allow if unset),
+// records the resulting PolicyDecision via collab.OnPolicyDecision, and
+// returns the (possibly transformed) text plus whether the call should
+// proceed at all.
+func checkPolicy(collab *Collaboration, req PolicyRequest) (text string, allowed bool) {
+	if collab.Policy == nil {
+		return req.Text, true
+	}
+
+	decision := collab.Policy.Evaluate(req)
+	if collab.OnPolicyDecision != nil {
+		collab.OnPolicyDecision(decision)
+	}
+	if decision.Effect == PolicyDeny {
+		return "", false
+	}
+	return decision.Text, true
+}
@@ -0,0 +1,161 @@
+package breeze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePolicyBundle(t *testing.T) {
+	rules, err := ParsePolicyBundle(`
+# comment lines and blanks are ignored
+
+allow qa_can_review {
+	input.agent.role == "QA Engineer"
+	input.action == "read"
+}
+
+deny {
+	input.action == "publish"
+}
+`)
+	if err != nil {
+		t.Fatalf("ParsePolicyBundle failed: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d: %+v", len(rules), rules)
+	}
+	if rules[0].Name != "qa_can_review" || rules[0].Effect != PolicyAllow || len(rules[0].Clauses) != 2 {
+		t.Errorf("Unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].Name != "deny_2" || rules[1].Effect != PolicyDeny {
+		t.Errorf("Expected an auto-named second rule, got %+v", rules[1])
+	}
+}
+
+func TestParsePolicyBundle_UnclosedRule(t *testing.T) {
+	if _, err := ParsePolicyBundle(`allow { input.action == "read"`); err == nil {
+		t.Error("Expected an error for a rule missing its closing brace")
+	}
+}
+
+func TestPolicyEngine_Evaluate_FirstMatchWins(t *testing.T) {
+	rules, err := ParsePolicyBundle(`
+allow qa_reads {
+	input.agent.role == "QA Engineer"
+	input.action == "read"
+}
+deny everything_else {
+	input.action == "read"
+}
+`)
+	if err != nil {
+		t.Fatalf("ParsePolicyBundle failed: %v", err)
+	}
+	engine := NewPolicyEngine(rules...)
+
+	qaDecision := engine.Evaluate(PolicyRequest{Agent: Agent{Name: "Quinn", Role: "QA Engineer"}, Action: PolicyRead, Text: "status?"})
+	if qaDecision.Effect != PolicyAllow || qaDecision.Rule != "qa_reads" {
+		t.Errorf("Expected the QA Engineer's read to match qa_reads and allow, got %+v", qaDecision)
+	}
+
+	otherDecision := engine.Evaluate(PolicyRequest{Agent: Agent{Name: "Dave", Role: "DevOps"}, Action: PolicyRead, Text: "status?"})
+	if otherDecision.Effect != PolicyDeny || otherDecision.Rule != "everything_else" {
+		t.Errorf("Expected a non-QA read to fall through to everything_else and deny, got %+v", otherDecision)
+	}
+}
+
+func TestPolicyEngine_Evaluate_NoMatchAllows(t *testing.T) {
+	engine := NewPolicyEngine()
+	decision := engine.Evaluate(PolicyRequest{Action: PolicyWrite, Text: "draft"})
+	if decision.Effect != PolicyAllow || decision.Text != "draft" {
+		t.Errorf("Expected an empty bundle to allow with text unchanged, got %+v", decision)
+	}
+}
+
+func TestPolicyEngine_Evaluate_Transform(t *testing.T) {
+	rule := PolicyRule{
+		Name:   "redact",
+		Effect: PolicyTransform,
+		Clauses: []policyClause{
+			{path: "action", want: string(PolicyPublish)},
+		},
+		Transform: func(req PolicyRequest) string { return "[redacted]" },
+	}
+	engine := NewPolicyEngine(rule)
+
+	decision := engine.Evaluate(PolicyRequest{Action: PolicyPublish, Text: "api-key=sk-live-123"})
+	if decision.Effect != PolicyTransform || decision.Text != "[redacted]" {
+		t.Errorf("Expected publish to be transformed, got %+v", decision)
+	}
+}
+
+func TestDefaultPolicyBundle_ProtectsSharedKnowledge(t *testing.T) {
+	engine := DefaultPolicyBundle()
+	alice := Agent{Name: "Alice"}
+	phase := Phase{Name: "Requirements", Produces: []string{"Summary"}}
+
+	ownWrite := engine.Evaluate(PolicyRequest{Agent: alice, Phase: phase, Object: "Alice", Action: PolicyWrite})
+	if ownWrite.Effect != PolicyAllow {
+		t.Errorf("Expected an agent writing its own key to be allowed, got %+v", ownWrite)
+	}
+
+	producedWrite := engine.Evaluate(PolicyRequest{Agent: alice, Phase: phase, Object: "Summary", Action: PolicyWrite})
+	if producedWrite.Effect != PolicyAllow {
+		t.Errorf("Expected a write to a Phase.Produces key to be allowed, got %+v", producedWrite)
+	}
+
+	overwrite := engine.Evaluate(PolicyRequest{Agent: alice, Phase: phase, Object: "Bob", Action: PolicyWrite})
+	if overwrite.Effect != PolicyDeny {
+		t.Errorf("Expected Alice overwriting Bob's key to be denied, got %+v", overwrite)
+	}
+
+	read := engine.Evaluate(PolicyRequest{Agent: alice, Phase: phase, Object: "Bob", Action: PolicyRead})
+	if read.Effect != PolicyAllow {
+		t.Errorf("Expected a read of another agent's key to be unaffected, got %+v", read)
+	}
+}
+
+func TestLoadPolicyBundle_Directory(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "a.rego"), "allow first {\n\tinput.action == \"read\"\n}\n")
+	mustWriteFile(t, filepath.Join(dir, "b.rego"), "deny second {\n\tinput.action == \"publish\"\n}\n")
+
+	rules, err := LoadPolicyBundle(dir)
+	if err != nil {
+		t.Fatalf("LoadPolicyBundle failed: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name != "first" || rules[1].Name != "second" {
+		t.Errorf("Expected rules from a.rego then b.rego in order, got %+v", rules)
+	}
+}
+
+func TestCollaboration_PolicyDeniesDecisionIsAudited(t *testing.T) {
+	var decisions []PolicyDecision
+	collab := &Collaboration{
+		SharedKnowledge: make(map[string]string),
+		Policy: NewPolicyEngine(PolicyRule{
+			Name:   "deny_writes",
+			Effect: PolicyDeny,
+			Clauses: []policyClause{
+				{path: "action", want: string(PolicyRead)},
+			},
+		}),
+		OnPolicyDecision: func(d PolicyDecision) { decisions = append(decisions, d) },
+	}
+
+	response := policyAI(collab, Agent{Name: "Alice"}, Phase{Name: "Design"}, "Alice", "design the widget", "design the widget")
+	if response != policyDeniedResponse("Alice") {
+		t.Errorf("Expected a denied read to short-circuit to the denial message, got %q", response)
+	}
+	if len(decisions) != 1 || decisions[0].Effect != PolicyDeny {
+		t.Errorf("Expected exactly one denied PolicyDecision to be recorded, got %+v", decisions)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
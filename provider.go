@@ -0,0 +1,685 @@
+package breeze
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Provider abstracts over a specific LLM vendor's API so AI/Chat/Code/Batch
+// and Collaboration agent turns aren't hardwired to Ollama. Agent.Provider
+// lets a single Collaboration mix providers per agent.
+type Provider interface {
+	Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error)
+	GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error)
+	Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error)
+	ListModels(ctx context.Context) ([]string, error)
+}
+
+// modelProviderPrefixes maps a model string's "<provider>:" prefix (e.g.
+// "openai:gpt-4o", "anthropic:claude-3-5-sonnet", "ollama:llama2") to the
+// Provider it selects, so a single model string can pick both without a
+// separate WithProvider call.
+var modelProviderPrefixes = map[string]Provider{
+	"openai":    OpenAIProvider{},
+	"anthropic": AnthropicProvider{},
+	"google":    GoogleProvider{},
+	"gemini":    GoogleProvider{},
+	"ollama":    OllamaProvider{},
+}
+
+// resolveModelPrefix splits a "<provider>:<model>" string into the Provider
+// it names and the bare model name. ok is false if model has no recognized
+// prefix, in which case model is returned unchanged.
+func resolveModelPrefix(model string) (provider Provider, bareModel string, ok bool) {
+	prefix, rest, found := strings.Cut(model, ":")
+	if !found {
+		return nil, model, false
+	}
+	p, known := modelProviderPrefixes[prefix]
+	if !known {
+		return nil, model, false
+	}
+	return p, rest, true
+}
+
+// applyModelProviderPrefix rewrites opts so a "<provider>:<model>" Model
+// string (e.g. "openai:gpt-4o") also selects that Provider, unless
+// WithProvider already pinned one explicitly. Called by AI/Chat/AIStream
+// right after applying Options.
+func applyModelProviderPrefix(opts *RequestOptions) {
+	provider, bareModel, ok := resolveModelPrefix(opts.Model)
+	if !ok {
+		return
+	}
+	opts.Model = bareModel
+	if opts.Provider == nil {
+		opts.Provider = provider
+	}
+}
+
+// defaultProvider is used by AI/Chat/Code when RequestOptions.Provider and
+// the BREEZE_PROVIDER env var are both unset. It preserves the library's
+// original hardcoded-Ollama behavior.
+var defaultProvider Provider = OllamaProvider{}
+
+// SetDefaultProvider installs p as the package-level default provider.
+func SetDefaultProvider(p Provider) {
+	defaultProvider = p
+}
+
+// WithProvider pins a request (or an Agent's turns) to a specific Provider,
+// overriding the package default and the BREEZE_PROVIDER env var.
+func WithProvider(p Provider) Option {
+	return func(opts *RequestOptions) {
+		opts.Provider = p
+	}
+}
+
+// resolveProvider picks, in priority order: an explicit opts.Provider, the
+// BREEZE_PROVIDER env var, then the package-level default.
+func resolveProvider(opts RequestOptions) Provider {
+	if opts.Provider != nil {
+		return opts.Provider
+	}
+	switch os.Getenv("BREEZE_PROVIDER") {
+	case "openai":
+		return OpenAIProvider{}
+	case "anthropic":
+		return AnthropicProvider{}
+	case "google", "gemini":
+		return GoogleProvider{}
+	case "ollama", "":
+		return defaultProvider
+	default:
+		return defaultProvider
+	}
+}
+
+// OllamaProvider talks to a local Ollama daemon, matching AI/Chat's original
+// hardcoded behavior.
+type OllamaProvider struct {
+	BaseURL string // defaults to http://localhost:11434
+}
+
+func (p OllamaProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return defaultClient.ollamaURL
+}
+
+func (p OllamaProvider) Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error) {
+	req := map[string]interface{}{
+		"model":  opts.Model,
+		"prompt": prompt,
+		"stream": false,
+	}
+	if opts.Temp != 0.7 {
+		req["options"] = map[string]interface{}{"temperature": opts.Temp}
+	}
+	if len(opts.Images) > 0 {
+		req["images"] = encodeImages(opts.Images)
+	}
+	jsonData, _ := json.Marshal(req)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/api/generate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("ollama request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(body, &result)
+	text, _ := result["response"].(string)
+	return text, nil
+}
+
+func (p OllamaProvider) GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error) {
+	tokens := make(chan Token)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(tokens)
+
+		req := map[string]interface{}{"model": opts.Model, "prompt": prompt, "stream": true}
+		if len(opts.Images) > 0 {
+			req["images"] = encodeImages(opts.Images)
+		}
+		jsonData, _ := json.Marshal(req)
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/api/generate", bytes.NewBuffer(jsonData))
+		if err != nil {
+			errCh <- err
+			return
+		}
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			errCh <- fmt.Errorf("ollama request failed: %w", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		start := time.Now()
+		count := 0
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var chunk map[string]interface{}
+			if err := decoder.Decode(&chunk); err != nil {
+				break
+			}
+			if text, ok := chunk["response"].(string); ok && text != "" {
+				count++
+				tokens <- Token{Text: text, TokensSoFar: count, ElapsedMs: time.Since(start).Milliseconds()}
+			}
+			if done, ok := chunk["done"].(bool); ok && done {
+				break
+			}
+		}
+		errCh <- nil
+	}()
+
+	return tokens, func() error { return <-errCh }
+}
+
+// Embed calls Ollama's /api/embeddings endpoint, matching the package-level
+// Embed helper's behavior.
+func (p OllamaProvider) Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error) {
+	model := opts.Model
+	if model == "" {
+		model = defaultEmbedModel
+	}
+	req := map[string]interface{}{"model": model, "prompt": text}
+	jsonData, _ := json.Marshal(req)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/api/embeddings", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("ollama embed request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embedding []float32 `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode ollama embed response: %w", err)
+	}
+	return result.Embedding, nil
+}
+
+// ListModels returns the models Ollama currently has pulled, via
+// /api/tags.
+func (p OllamaProvider) ListModels(ctx context.Context) ([]string, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL()+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("ollama list models failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode ollama tags response: %w", err)
+	}
+	names := make([]string, len(result.Models))
+	for i, m := range result.Models {
+		names[i] = m.Name
+	}
+	return names, nil
+}
+
+// OpenAIProvider calls the OpenAI chat completions API. The API key is read
+// from the OPENAI_API_KEY env var.
+type OpenAIProvider struct {
+	Model   string // defaults to "gpt-4o-mini"
+	BaseURL string // defaults to https://api.openai.com/v1
+}
+
+func (p OpenAIProvider) model(opts RequestOptions) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	if p.Model != "" {
+		return p.Model
+	}
+	return "gpt-4o-mini"
+}
+
+func (p OpenAIProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return "https://api.openai.com/v1"
+}
+
+func (p OpenAIProvider) Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model":       p.model(opts),
+		"messages":    []map[string]interface{}{{"role": "user", "content": openAIContent(opts, prompt)}},
+		"temperature": opts.Temp,
+	}
+	jsonData, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode openai response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("openai returned no choices")
+	}
+	return result.Choices[0].Message.Content, nil
+}
+
+func (p OpenAIProvider) GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error) {
+	return unsupportedStream("OpenAIProvider")
+}
+
+// Embed calls OpenAI's /embeddings endpoint, defaulting to the
+// text-embedding-3-small model.
+func (p OpenAIProvider) Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error) {
+	model := "text-embedding-3-small"
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	body := map[string]interface{}{"model": model, "input": text}
+	jsonData, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/embeddings", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embed request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode openai embed response: %w", err)
+	}
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("openai returned no embeddings")
+	}
+	return result.Data[0].Embedding, nil
+}
+
+// ListModels returns the model IDs OpenAI's account has access to, via
+// GET /models.
+func (p OpenAIProvider) ListModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL()+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai list models failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode openai models response: %w", err)
+	}
+	ids := make([]string, len(result.Data))
+	for i, m := range result.Data {
+		ids[i] = m.ID
+	}
+	return ids, nil
+}
+
+// openAIContent builds a chat message's "content" field: a plain string when
+// there are no images, or gpt-4o's multi-part content array (text plus
+// data-URI image_url parts) when there are.
+func openAIContent(opts RequestOptions, prompt string) interface{} {
+	if len(opts.Images) == 0 {
+		return prompt
+	}
+	parts := []map[string]interface{}{{"type": "text", "text": prompt}}
+	for _, img := range opts.Images {
+		mimeType, data := imageMIMEAndData(img)
+		parts = append(parts, map[string]interface{}{
+			"type":      "image_url",
+			"image_url": map[string]string{"url": fmt.Sprintf("data:%s;base64,%s", mimeType, data)},
+		})
+	}
+	return parts
+}
+
+// AnthropicProvider calls the Anthropic Messages API. The API key is read
+// from the ANTHROPIC_API_KEY env var.
+type AnthropicProvider struct {
+	Model   string // defaults to "claude-3-5-sonnet-20241022"
+	BaseURL string // defaults to https://api.anthropic.com/v1
+}
+
+func (p AnthropicProvider) model(opts RequestOptions) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	if p.Model != "" {
+		return p.Model
+	}
+	return "claude-3-5-sonnet-20241022"
+}
+
+func (p AnthropicProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return "https://api.anthropic.com/v1"
+}
+
+func (p AnthropicProvider) Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model":      p.model(opts),
+		"max_tokens": 4096,
+		"messages":   []map[string]interface{}{{"role": "user", "content": anthropicContent(opts, prompt)}},
+	}
+	jsonData, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL()+"/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", os.Getenv("ANTHROPIC_API_KEY"))
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("anthropic request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode anthropic response: %w", err)
+	}
+	if len(result.Content) == 0 {
+		return "", fmt.Errorf("anthropic returned no content")
+	}
+	return result.Content[0].Text, nil
+}
+
+func (p AnthropicProvider) GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error) {
+	return unsupportedStream("AnthropicProvider")
+}
+
+// Embed always fails: Anthropic has no public embeddings API.
+func (p AnthropicProvider) Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error) {
+	return nil, fmt.Errorf("AnthropicProvider does not support embeddings")
+}
+
+// ListModels returns the model IDs available to the account, via
+// GET /models.
+func (p AnthropicProvider) ListModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL()+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", os.Getenv("ANTHROPIC_API_KEY"))
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic list models failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode anthropic models response: %w", err)
+	}
+	ids := make([]string, len(result.Data))
+	for i, m := range result.Data {
+		ids[i] = m.ID
+	}
+	return ids, nil
+}
+
+// anthropicContent builds a message's "content" field: a plain string when
+// there are no images, or Claude 3's content-block array (image blocks
+// followed by a text block) when there are.
+func anthropicContent(opts RequestOptions, prompt string) interface{} {
+	if len(opts.Images) == 0 {
+		return prompt
+	}
+	var blocks []map[string]interface{}
+	for _, img := range opts.Images {
+		mimeType, data := imageMIMEAndData(img)
+		blocks = append(blocks, map[string]interface{}{
+			"type":   "image",
+			"source": map[string]string{"type": "base64", "media_type": mimeType, "data": data},
+		})
+	}
+	blocks = append(blocks, map[string]interface{}{"type": "text", "text": prompt})
+	return blocks
+}
+
+// GoogleProvider calls the Gemini generateContent API. The API key is read
+// from the GOOGLE_API_KEY env var.
+type GoogleProvider struct {
+	Model   string // defaults to "gemini-1.5-flash"
+	BaseURL string // defaults to https://generativelanguage.googleapis.com/v1beta
+}
+
+func (p GoogleProvider) model(opts RequestOptions) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	if p.Model != "" {
+		return p.Model
+	}
+	return "gemini-1.5-flash"
+}
+
+func (p GoogleProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return "https://generativelanguage.googleapis.com/v1beta"
+}
+
+func (p GoogleProvider) Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{"parts": googleParts(opts, prompt)},
+		},
+	}
+	jsonData, _ := json.Marshal(body)
+
+	url := fmt.Sprintf("%s/models/%s:generateContent?key=%s", p.baseURL(), p.model(opts), os.Getenv("GOOGLE_API_KEY"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("google request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode google response: %w", err)
+	}
+	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("google returned no candidates")
+	}
+	return result.Candidates[0].Content.Parts[0].Text, nil
+}
+
+func (p GoogleProvider) GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error) {
+	return unsupportedStream("GoogleProvider")
+}
+
+// Embed calls Gemini's embedContent endpoint, defaulting to the
+// text-embedding-004 model.
+func (p GoogleProvider) Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error) {
+	model := "text-embedding-004"
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	body := map[string]interface{}{
+		"content": map[string]interface{}{"parts": []map[string]interface{}{{"text": text}}},
+	}
+	jsonData, _ := json.Marshal(body)
+
+	url := fmt.Sprintf("%s/models/%s:embedContent?key=%s", p.baseURL(), model, os.Getenv("GOOGLE_API_KEY"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google embed request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode google embed response: %w", err)
+	}
+	return result.Embedding.Values, nil
+}
+
+// ListModels returns the model names Gemini currently exposes, via
+// GET /models.
+func (p GoogleProvider) ListModels(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/models?key=%s", p.baseURL(), os.Getenv("GOOGLE_API_KEY"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google list models failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode google models response: %w", err)
+	}
+	names := make([]string, len(result.Models))
+	for i, m := range result.Models {
+		names[i] = m.Name
+	}
+	return names, nil
+}
+
+// googleParts builds a generateContent request's "parts" field: a single
+// text part when there are no images, or inlineData image parts followed by
+// the text part when there are.
+func googleParts(opts RequestOptions, prompt string) []map[string]interface{} {
+	parts := make([]map[string]interface{}, 0, len(opts.Images)+1)
+	for _, img := range opts.Images {
+		mimeType, data := imageMIMEAndData(img)
+		parts = append(parts, map[string]interface{}{
+			"inlineData": map[string]string{"mimeType": mimeType, "data": data},
+		})
+	}
+	parts = append(parts, map[string]interface{}{"text": prompt})
+	return parts
+}
+
+// imageMIMEAndData sniffs img's content type and base64-encodes it, for
+// providers whose vision APIs want both alongside the raw bytes.
+func imageMIMEAndData(img []byte) (mimeType, data string) {
+	return http.DetectContentType(img), base64.StdEncoding.EncodeToString(img)
+}
+
+// unsupportedStream returns a channel that closes immediately with an error,
+// for providers that don't yet implement token streaming.
+func unsupportedStream(providerName string) (<-chan Token, func() error) {
+	tokens := make(chan Token)
+	close(tokens)
+	return tokens, func() error {
+		return fmt.Errorf("%s does not support streaming yet", providerName)
+	}
+}
@@ -0,0 +1,334 @@
+package breeze
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultEmbedModel is used by Embed/EmbedBatch/Index when the caller
+// doesn't set options.Model to a specific embedding model.
+const defaultEmbedModel = "nomic-embed-text"
+
+// defaultChunkTokens and defaultChunkOverlap size the chunks Index splits
+// each document into, approximated as whitespace-separated words rather
+// than true model tokens (the same rough approximation BatchResult uses for
+// token counts).
+const (
+	defaultChunkTokens  = 200
+	defaultChunkOverlap = 40
+)
+
+// defaultRAGTopK is how many chunks WithRAG retrieves when RAGTopK is unset.
+const defaultRAGTopK = 4
+
+// Embed returns text's embedding vector via options.Provider (WithProvider),
+// or the BREEZE_PROVIDER/package-level default (see resolveProvider) if
+// unset, using options.Model if set (via WithModel) or defaultEmbedModel
+// otherwise.
+func Embed(text string, opts ...Option) ([]float32, error) {
+	options := RequestOptions{Model: defaultEmbedModel}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	provider := resolveProvider(options)
+	vector, err := provider.Embed(reqCtx(options), options, text)
+	if err != nil {
+		return nil, fmt.Errorf("embed request failed: %w", err)
+	}
+	return vector, nil
+}
+
+// EmbedBatch embeds each of texts, preserving order.
+func EmbedBatch(texts []string, opts ...Option) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v, err := Embed(text, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("embed chunk %d: %w", i, err)
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+// chunkText splits text into overlapping word-count windows of size
+// tokenSize, sharing overlap words between consecutive chunks so a
+// retrieved chunk doesn't lose context that fell right on a boundary.
+func chunkText(text string, tokenSize, overlap int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if tokenSize <= 0 {
+		tokenSize = defaultChunkTokens
+	}
+	if overlap < 0 || overlap >= tokenSize {
+		overlap = defaultChunkOverlap
+	}
+
+	var chunks []string
+	step := tokenSize - overlap
+	for start := 0; start < len(words); start += step {
+		end := start + tokenSize
+		if end > len(words) {
+			end = len(words)
+		}
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// VectorChunk is one embedded, retrievable unit of text stored in a
+// VectorStore.
+type VectorChunk struct {
+	ID     string
+	Text   string
+	Vector []float32
+	Source string // the file path this chunk was extracted from
+}
+
+// VectorMatch is a VectorChunk returned by VectorStore.Search, ranked by
+// similarity to the query vector.
+type VectorMatch struct {
+	VectorChunk
+	Score float32
+}
+
+// VectorStore persists embedded document chunks and retrieves the ones most
+// similar to a query vector, backing WithRAG and Index.
+type VectorStore interface {
+	Add(chunks ...VectorChunk) error
+	Search(query []float32, k int) ([]VectorMatch, error)
+}
+
+// cosineSimilarity scores how alike two embedding vectors are, in [-1, 1].
+func cosineSimilarity(a, b []float32) float32 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	var dot, normA, normB float64
+	for i := 0; i < n; i++ {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+// topKByScore ranks chunks against query by cosine similarity and returns
+// the top k (or all of them if k <= 0 or there are fewer than k).
+func topKByScore(chunks []VectorChunk, query []float32, k int) []VectorMatch {
+	matches := make([]VectorMatch, len(chunks))
+	for i, c := range chunks {
+		matches[i] = VectorMatch{VectorChunk: c, Score: cosineSimilarity(query, c.Vector)}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if k > 0 && k < len(matches) {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// InMemoryVectorStore is a VectorStore backed by a slice searched by
+// brute-force cosine similarity. Fine up to a few thousand chunks; a corpus
+// too large for that should use a VectorStore backed by an ANN index (e.g.
+// HNSW) instead, which this package doesn't implement to stay
+// dependency-free.
+type InMemoryVectorStore struct {
+	mu     sync.Mutex
+	chunks []VectorChunk
+}
+
+// NewInMemoryVectorStore creates an empty InMemoryVectorStore.
+func NewInMemoryVectorStore() *InMemoryVectorStore {
+	return &InMemoryVectorStore{}
+}
+
+func (s *InMemoryVectorStore) Add(chunks ...VectorChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = append(s.chunks, chunks...)
+	return nil
+}
+
+func (s *InMemoryVectorStore) Search(query []float32, k int) ([]VectorMatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return topKByScore(s.chunks, query, k), nil
+}
+
+// FileVectorStore persists chunks as JSON lines under Path and does
+// brute-force cosine search over them in memory, loading the file once on
+// first use. This keeps the store dependency-free (no BoltDB/SQLite driver
+// needed) while meeting the same add/search contract a DB-backed store
+// would — the same tradeoff FileConversationStore makes for conversation
+// history.
+type FileVectorStore struct {
+	Path string
+
+	mu     sync.Mutex
+	loaded bool
+	chunks []VectorChunk
+}
+
+// NewFileVectorStore creates a store that persists chunks to path, creating
+// its parent directory (and the file) as needed on the first Add.
+func NewFileVectorStore(path string) *FileVectorStore {
+	return &FileVectorStore{Path: path}
+}
+
+func (s *FileVectorStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	s.loaded = true
+
+	file, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open vector store %s: %w", s.Path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var c VectorChunk
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			continue
+		}
+		s.chunks = append(s.chunks, c)
+	}
+	return scanner.Err()
+}
+
+func (s *FileVectorStore) Add(chunks ...VectorChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(s.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create vector store dir: %w", err)
+		}
+	}
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open vector store %s: %w", s.Path, err)
+	}
+	defer file.Close()
+
+	for _, c := range chunks {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("marshal vector chunk: %w", err)
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("append to vector store %s: %w", s.Path, err)
+		}
+		s.chunks = append(s.chunks, c)
+	}
+	return nil
+}
+
+func (s *FileVectorStore) Search(query []float32, k int) ([]VectorMatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return topKByScore(s.chunks, query, k), nil
+}
+
+// Index extracts text from each of paths (see extractTextFromFile), splits
+// it into overlapping chunks (see chunkText), embeds every chunk (see
+// EmbedBatch), and adds them to store, so a later WithRAG(store, k) can
+// retrieve the most relevant ones.
+func Index(store VectorStore, paths []string, opts ...Option) error {
+	options := RequestOptions{Model: defaultEmbedModel}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for _, path := range paths {
+		text, err := extractTextFromFile(path, options)
+		if err != nil {
+			return fmt.Errorf("index %s: %w", path, err)
+		}
+
+		chunks := chunkText(text, defaultChunkTokens, defaultChunkOverlap)
+		if len(chunks) == 0 {
+			continue
+		}
+
+		vectors, err := EmbedBatch(chunks, opts...)
+		if err != nil {
+			return fmt.Errorf("index %s: %w", path, err)
+		}
+
+		vectorChunks := make([]VectorChunk, len(chunks))
+		for i, c := range chunks {
+			vectorChunks[i] = VectorChunk{
+				ID:     fmt.Sprintf("%s#%d", path, i),
+				Text:   c,
+				Vector: vectors[i],
+				Source: path,
+			}
+		}
+		if err := store.Add(vectorChunks...); err != nil {
+			return fmt.Errorf("index %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ragContext embeds prompt, retrieves options.RAGTopK chunks most relevant
+// to it from options.RAGStore, and joins their text to use as context.
+// options.Docs, if set, are indexed into the store first.
+func ragContext(prompt string, options RequestOptions) (string, error) {
+	if len(options.Docs) > 0 {
+		if err := Index(options.RAGStore, options.Docs, WithCtx(options.Ctx)); err != nil {
+			return "", err
+		}
+	}
+
+	queryVec, err := Embed(prompt, WithCtx(options.Ctx))
+	if err != nil {
+		return "", err
+	}
+
+	k := options.RAGTopK
+	if k <= 0 {
+		k = defaultRAGTopK
+	}
+	matches, err := options.RAGStore.Search(queryVec, k)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, m := range matches {
+		b.WriteString(m.Text)
+		b.WriteString("\n\n")
+	}
+	return strings.TrimSpace(b.String()), nil
+}
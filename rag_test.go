@@ -0,0 +1,50 @@
+package breeze
+
+import (
+	"context"
+	"testing"
+)
+
+// recordingEmbedProvider is a Provider stub that records the text it was
+// asked to embed and returns a fixed vector, so a test can assert Embed
+// actually dispatched through WithProvider instead of always hitting
+// Ollama.
+type recordingEmbedProvider struct {
+	embedded []string
+}
+
+func (p *recordingEmbedProvider) Generate(_ context.Context, _ RequestOptions, _ string) (string, error) {
+	return "", nil
+}
+
+func (p *recordingEmbedProvider) GenerateStream(_ context.Context, _ RequestOptions, _ string) (<-chan Token, func() error) {
+	ch := make(chan Token)
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+func (p *recordingEmbedProvider) Embed(_ context.Context, _ RequestOptions, text string) ([]float32, error) {
+	p.embedded = append(p.embedded, text)
+	return []float32{1, 2, 3}, nil
+}
+
+func (p *recordingEmbedProvider) ListModels(_ context.Context) ([]string, error) {
+	return nil, nil
+}
+
+// TestEmbed_RoutesThroughWithProvider checks that Embed honors WithProvider
+// instead of always hitting Ollama's /api/embeddings endpoint directly.
+func TestEmbed_RoutesThroughWithProvider(t *testing.T) {
+	provider := &recordingEmbedProvider{}
+
+	vector, err := Embed("hello world", WithProvider(provider))
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(provider.embedded) != 1 || provider.embedded[0] != "hello world" {
+		t.Fatalf("Expected Embed to call the configured Provider's Embed once with the text, got %+v", provider.embedded)
+	}
+	if len(vector) != 3 {
+		t.Errorf("Expected the provider's vector to be returned unchanged, got %v", vector)
+	}
+}
@@ -0,0 +1,189 @@
+package scaletest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/user/breeze"
+)
+
+// CollabSpec describes one scale test run against a bare breeze.Collaboration
+// exercising a single built-in CollaborationMethod, as opposed to Spec, which
+// drives a whole TeamCollaboration loaded from a spec file. It's the
+// load-test shape: push a chosen method at a given concurrency for a given
+// duration and see what breaks, e.g.
+//
+//	CollabSpec{Method: "parallel", MethodParam: 8, Agents: 5,
+//	  Input: "design a caching layer", Duration: "30s", Concurrency: 20}
+type CollabSpec struct {
+	// Method names a built-in CollaborationMethod constructor: "sequential",
+	// "parallel", "peer_review", "consensus", or "debate".
+	Method string `json:"method" yaml:"method"`
+	// MethodParam is passed to the named constructor: maxConcurrency for
+	// "parallel"/"peer_review"/"consensus", rounds for "debate". Ignored for
+	// "sequential".
+	MethodParam int `json:"method_param" yaml:"method_param"`
+	// Agents is how many synthetic agents populate the single phase under
+	// test.
+	Agents int `json:"agents" yaml:"agents"`
+	// Input is the initial prompt passed to Collaboration.Run on every run.
+	Input string `json:"input" yaml:"input"`
+	// Duration bounds total wall-clock time (e.g. "30s"), parsed via
+	// time.ParseDuration. Each worker keeps running the collaboration back
+	// to back until Duration elapses.
+	Duration string `json:"duration" yaml:"duration"`
+	// Concurrency is how many workers run the collaboration concurrently.
+	// <= 0 means 1.
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Output names the Reporter to use when CollabRunner.Run isn't given one
+	// explicitly: "json", "text", or "csv".
+	Output string `json:"output" yaml:"output"`
+}
+
+// collabMethod resolves spec.Method/MethodParam to a CollaborationMethod,
+// mirroring the names a breeze.CollaborationSpec YAML file would use.
+func collabMethod(spec CollabSpec) (breeze.CollaborationMethod, error) {
+	switch spec.Method {
+	case "sequential", "":
+		return breeze.Sequential(), nil
+	case "parallel":
+		return breeze.Parallel(spec.MethodParam), nil
+	case "peer_review":
+		return breeze.PeerReview(spec.MethodParam), nil
+	case "consensus":
+		return breeze.Consensus(spec.MethodParam), nil
+	case "debate":
+		return breeze.DebateStyle(spec.MethodParam), nil
+	default:
+		return nil, fmt.Errorf("scaletest: unknown CollabSpec.Method %q", spec.Method)
+	}
+}
+
+// newCollabAgents builds n synthetic agents for CollabRunner, numbered
+// Agent1..AgentN so Report's per-agent breakdown stays readable regardless
+// of Agents.
+func newCollabAgents(n int) []breeze.Agent {
+	if n <= 0 {
+		n = 1
+	}
+	agents := make([]breeze.Agent, n)
+	for i := range agents {
+		agents[i] = breeze.Agent{Name: fmt.Sprintf("Agent%d", i+1), Role: "benchmark participant"}
+	}
+	return agents
+}
+
+// CollabRunner executes a CollabSpec and produces a Report, reusing Report/
+// RunResult/aggregate so breeze support inspect, Runner.Run, and
+// CollabRunner.Run all render through the same Reporter implementations.
+type CollabRunner struct {
+	Spec CollabSpec
+	// Reporter, if set, has Run render the Report through it in addition to
+	// returning it. Nil means Run only returns the Report.
+	Reporter Reporter
+	// Metrics, if set, is kept current with the latest Report as the run
+	// progresses (every completed collaboration run), so a concurrently
+	// running MetricsHandler.ServeHTTP can be scraped mid-test, not just at
+	// the end.
+	Metrics *MetricsHandler
+}
+
+// NewCollabRunner creates a CollabRunner for spec, defaulting its Reporter
+// from spec.Output.
+func NewCollabRunner(spec CollabSpec) *CollabRunner {
+	return &CollabRunner{Spec: spec, Reporter: reporterFor(spec.Output)}
+}
+
+// Run drives Spec.Concurrency workers, each looping
+// Collaboration.Run(Spec.Input) back to back against a fresh Collaboration
+// (so concurrent runs don't share SharedKnowledge state) until Spec.Duration
+// elapses, aggregates every RunResult into a Report, renders it through
+// r.Reporter if set, and returns it.
+func (r *CollabRunner) Run() (*Report, error) {
+	method, err := collabMethod(r.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := 10 * time.Second
+	if r.Spec.Duration != "" {
+		duration, err = time.ParseDuration(r.Spec.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("scaletest: invalid duration %q: %w", r.Spec.Duration, err)
+		}
+	}
+
+	concurrency := r.Spec.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		results []RunResult
+		wg      sync.WaitGroup
+	)
+	deadline := time.Now().Add(duration)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				result := r.runCollabOnce(method)
+				mu.Lock()
+				results = append(results, result)
+				if r.Metrics != nil {
+					r.Metrics.Update(aggregate(results))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := aggregate(results)
+	if r.Reporter != nil {
+		if err := r.Reporter.Report(report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// runCollabOnce builds a fresh single-phase Collaboration using method and
+// Spec.Agents agents, runs it once against Spec.Input, and folds the result
+// into a RunResult the same shape runOnce (TeamCollaboration) produces, so
+// both paths share Report/aggregate/percentile.
+func (r *CollabRunner) runCollabOnce(method breeze.CollaborationMethod) RunResult {
+	agents := newCollabAgents(r.Spec.Agents)
+	collab := breeze.NewCollaboration(agents, []breeze.Phase{
+		{Name: "Benchmark", Description: "scaletest benchmark phase", Method: method},
+	})
+
+	result := RunResult{
+		PhaseDurations: make(map[string]time.Duration),
+		AgentTokens:    make(map[string]int),
+	}
+
+	// A Method-driven phase (the only kind CollabRunner builds) returns its
+	// results straight from phase.Method, bypassing runPhase's
+	// OnAgentResponse call - so per-agent tokens are read from
+	// OnPhaseComplete's results map instead.
+	var mu sync.Mutex
+	phaseStart := time.Now()
+	collab.OnPhaseComplete = func(phaseName string, phaseResults map[string]string) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.PhaseDurations[phaseName] = time.Since(phaseStart)
+		for agentName, response := range phaseResults {
+			result.AgentTokens[agentName] += len(response) / 4
+			result.SharedKnowledgeSize += len(response)
+		}
+	}
+
+	_, err := collab.Run(r.Spec.Input)
+	result.Err = err
+	return result
+}
@@ -0,0 +1,80 @@
+package scaletest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/user/breeze"
+)
+
+func TestCollabMethod(t *testing.T) {
+	if _, err := collabMethod(CollabSpec{Method: "bogus"}); err == nil {
+		t.Error("Expected an unknown Method to error")
+	}
+	for _, method := range []string{"sequential", "", "parallel", "peer_review", "consensus", "debate"} {
+		if _, err := collabMethod(CollabSpec{Method: method, MethodParam: 2}); err != nil {
+			t.Errorf("collabMethod(%q) failed: %v", method, err)
+		}
+	}
+}
+
+func TestNewCollabAgents(t *testing.T) {
+	if agents := newCollabAgents(0); len(agents) != 1 {
+		t.Errorf("Expected newCollabAgents(0) to default to 1 agent, got %d", len(agents))
+	}
+	agents := newCollabAgents(3)
+	if len(agents) != 3 || agents[0].Name != "Agent1" || agents[2].Name != "Agent3" {
+		t.Errorf("Expected Agent1..Agent3, got %+v", agents)
+	}
+}
+
+func TestCollabRunner_Run(t *testing.T) {
+	breeze.SetDefaultProvider(MockAI{Latency: time.Millisecond})
+	defer breeze.SetDefaultProvider(breeze.OllamaProvider{})
+
+	runner := NewCollabRunner(CollabSpec{
+		Method:      "sequential",
+		Agents:      2,
+		Input:       "benchmark prompt",
+		Duration:    "20ms",
+		Concurrency: 2,
+	})
+	report, err := runner.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.Runs == 0 {
+		t.Fatal("Expected at least one completed run in 20ms of MockAI-backed work")
+	}
+	if len(report.Agents) != 2 {
+		t.Errorf("Expected 2 agents in the report, got %+v", report.Agents)
+	}
+}
+
+func TestMockAI_Generate(t *testing.T) {
+	m := MockAI{Latency: time.Millisecond, ResponseTokens: 10}
+	resp, err := m.Generate(context.Background(), breeze.RequestOptions{}, "hi")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(resp) == 0 {
+		t.Error("Expected a non-empty synthetic response")
+	}
+}
+
+func TestMockAI_ErrorRate(t *testing.T) {
+	m := MockAI{ErrorRate: 1}
+	if _, err := m.Generate(context.Background(), breeze.RequestOptions{}, "hi"); err == nil {
+		t.Error("Expected ErrorRate: 1 to always fail")
+	}
+}
+
+func TestMockAI_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := MockAI{Latency: time.Hour}
+	if _, err := m.Generate(ctx, breeze.RequestOptions{}, "hi"); err == nil {
+		t.Error("Expected a canceled context to abort Generate")
+	}
+}
@@ -0,0 +1,100 @@
+package scaletest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MetricsHandler serves the most recent Report in Prometheus text exposition
+// format, so a scale test can be scraped the same way breeze's own /metrics
+// endpoint would be (see server/handlers.go), instead of only producing a
+// one-shot Report at the end of a run.
+type MetricsHandler struct {
+	mu     sync.RWMutex
+	report *Report
+}
+
+// NewMetricsHandler creates a MetricsHandler with no report yet recorded;
+// ServeHTTP responds with an empty exposition until Update is called.
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{}
+}
+
+// Update replaces the report served by ServeHTTP. Call it after every
+// Runner.Run (or periodically from a long-running scale test) to keep a
+// scraper's view current.
+func (h *MetricsHandler) Update(report *Report) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.report = report
+}
+
+// ServeHTTP implements http.Handler, writing the current report as
+// Prometheus text exposition format.
+func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.mu.RLock()
+	report := h.report
+	h.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if report == nil {
+		return
+	}
+	fmt.Fprint(w, renderPrometheusMetrics(report))
+}
+
+// renderPrometheusMetrics formats report as Prometheus text exposition
+// format: scalar gauges for run/error counts, and per-phase/per-agent
+// gauges labeled by name.
+func renderPrometheusMetrics(report *Report) string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_runs_total Total collaboration runs executed.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_runs_total gauge")
+	fmt.Fprintf(&b, "breeze_scaletest_runs_total %d\n", report.Runs)
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_errors_total Collaboration runs that errored.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_errors_total gauge")
+	fmt.Fprintf(&b, "breeze_scaletest_errors_total %d\n", report.Errors)
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_error_rate Fraction of runs that errored.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_error_rate gauge")
+	fmt.Fprintf(&b, "breeze_scaletest_error_rate %f\n", report.ErrorRate)
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_phase_duration_seconds Phase latency percentiles.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_phase_duration_seconds gauge")
+	for _, phase := range report.Phases {
+		for _, pct := range []struct {
+			label string
+			value float64
+		}{
+			{"p50", phase.P50.Seconds()},
+			{"p95", phase.P95.Seconds()},
+			{"p99", phase.P99.Seconds()},
+		} {
+			fmt.Fprintf(&b, "breeze_scaletest_phase_duration_seconds{phase=%q,quantile=%q} %f\n", phase.Name, pct.label, pct.value)
+		}
+	}
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_agent_tokens_total Estimated tokens produced per agent.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_agent_tokens_total gauge")
+	for _, agent := range report.Agents {
+		fmt.Fprintf(&b, "breeze_scaletest_agent_tokens_total{agent=%q} %d\n", agent.Name, agent.TotalTokens)
+	}
+
+	fmt.Fprintln(&b, "# HELP breeze_scaletest_shared_knowledge_size_kb_bucket Runs bucketed by final SharedKnowledge size in KB.")
+	fmt.Fprintln(&b, "# TYPE breeze_scaletest_shared_knowledge_size_kb_bucket gauge")
+	buckets := make([]int, 0, len(report.SharedKnowledgeHistogram))
+	for kb := range report.SharedKnowledgeHistogram {
+		buckets = append(buckets, kb)
+	}
+	sort.Ints(buckets)
+	for _, kb := range buckets {
+		fmt.Fprintf(&b, "breeze_scaletest_shared_knowledge_size_kb_bucket{kb=\"%d\"} %d\n", kb, report.SharedKnowledgeHistogram[kb])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package scaletest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/user/breeze"
+)
+
+// MockAI is a breeze.Provider that never calls a real LLM: Generate sleeps
+// Latency (+/- Jitter) and returns a synthetic response, so CollabRunner can
+// measure a CollaborationMethod's own orchestration overhead (goroutine
+// fan-out, semaphore contention in Parallel(maxConcurrency), SharedKnowledge
+// locking) without burning real API calls or being dominated by network/model
+// latency. Install it with breeze.SetDefaultProvider(MockAI{...}) before
+// running the Collaboration under test.
+type MockAI struct {
+	// Latency is the average simulated Generate call duration.
+	Latency time.Duration
+	// Jitter adds up to +/-Jitter of random variance to Latency, so latency
+	// percentiles (P50/P95/P99) aren't degenerate.
+	Jitter time.Duration
+	// ErrorRate is the fraction (0..1) of calls that fail instead of
+	// returning a response, to exercise Report's error-rate tracking.
+	ErrorRate float64
+	// ResponseTokens sizes the synthetic response (ResponseTokens*4 bytes,
+	// this package's token-estimate convention). Zero means 50.
+	ResponseTokens int
+}
+
+// Generate implements breeze.Provider.
+func (m MockAI) Generate(ctx context.Context, opts breeze.RequestOptions, prompt string) (string, error) {
+	select {
+	case <-time.After(m.delay()):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	if m.ErrorRate > 0 && rand.Float64() < m.ErrorRate {
+		return "", fmt.Errorf("scaletest: mock provider simulated failure")
+	}
+	return m.response(), nil
+}
+
+// GenerateStream implements breeze.Provider, delivering the same response as
+// Generate as a single token after the simulated delay.
+func (m MockAI) GenerateStream(ctx context.Context, opts breeze.RequestOptions, prompt string) (<-chan breeze.Token, func() error) {
+	tokens := make(chan breeze.Token, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(tokens)
+		select {
+		case <-time.After(m.delay()):
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		}
+		if m.ErrorRate > 0 && rand.Float64() < m.ErrorRate {
+			errCh <- fmt.Errorf("scaletest: mock provider simulated failure")
+			return
+		}
+		text := m.response()
+		tokens <- breeze.Token{Text: text, TokensSoFar: len(text) / 4, ElapsedMs: m.Latency.Milliseconds()}
+		errCh <- nil
+	}()
+
+	return tokens, func() error { return <-errCh }
+}
+
+// Embed implements breeze.Provider. MockAI has no use for embeddings, so it
+// always errors rather than returning a meaningless vector.
+func (m MockAI) Embed(ctx context.Context, opts breeze.RequestOptions, text string) ([]float32, error) {
+	return nil, fmt.Errorf("scaletest: MockAI does not support Embed")
+}
+
+// ListModels implements breeze.Provider.
+func (m MockAI) ListModels(ctx context.Context) ([]string, error) {
+	return []string{"mock"}, nil
+}
+
+func (m MockAI) delay() time.Duration {
+	delay := m.Latency
+	if m.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(2*m.Jitter))) - m.Jitter
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	return delay
+}
+
+func (m MockAI) response() string {
+	tokens := m.ResponseTokens
+	if tokens == 0 {
+		tokens = 50
+	}
+	words := strings.Repeat("lorem ipsum dolor sit amet ", tokens/5+1)
+	bytes := tokens * 4
+	if bytes > len(words) {
+		return words
+	}
+	return words[:bytes]
+}
@@ -0,0 +1,125 @@
+package scaletest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Reporter renders a completed Report somewhere - stdout, a file, a
+// dashboard ingestion pipeline. Runner.Run calls Report once after
+// aggregating every run.
+type Reporter interface {
+	Report(report *Report) error
+}
+
+// JSONReporter writes report as indented JSON to Writer (os.Stdout if nil).
+type JSONReporter struct {
+	Writer io.Writer
+}
+
+// Report implements Reporter.
+func (r JSONReporter) Report(report *Report) error {
+	w := r.writer()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func (r JSONReporter) writer() io.Writer {
+	if r.Writer != nil {
+		return r.Writer
+	}
+	return os.Stdout
+}
+
+// TextTableReporter writes report as human-readable tables to Writer
+// (os.Stdout if nil): one row per phase with latency percentiles, one row
+// per agent with token totals, and a summary line.
+type TextTableReporter struct {
+	Writer io.Writer
+}
+
+// Report implements Reporter.
+func (r TextTableReporter) Report(report *Report) error {
+	w := r.writer()
+	fmt.Fprintf(w, "Runs: %d  Errors: %d  Error rate: %.2f%%\n\n", report.Runs, report.Errors, report.ErrorRate*100)
+
+	fmt.Fprintln(w, "PHASE                COUNT  P50          P95          P99")
+	for _, phase := range report.Phases {
+		fmt.Fprintf(w, "%-20s %-6d %-12s %-12s %s\n", phase.Name, phase.Count, phase.P50, phase.P95, phase.P99)
+	}
+
+	fmt.Fprintln(w, "\nAGENT                RUNS   TOTAL TOKENS")
+	for _, agent := range report.Agents {
+		fmt.Fprintf(w, "%-20s %-6d %d\n", agent.Name, agent.Runs, agent.TotalTokens)
+	}
+	return nil
+}
+
+func (r TextTableReporter) writer() io.Writer {
+	if r.Writer != nil {
+		return r.Writer
+	}
+	return os.Stdout
+}
+
+// CSVReporter writes one CSV row per phase (kind=phase) followed by one row
+// per agent (kind=agent) to Writer (os.Stdout if nil), so a report can be
+// loaded into a spreadsheet or appended to a history file across runs.
+type CSVReporter struct {
+	Writer io.Writer
+}
+
+// Report implements Reporter.
+func (r CSVReporter) Report(report *Report) error {
+	w := csv.NewWriter(r.writer())
+	defer w.Flush()
+
+	if err := w.Write([]string{"kind", "name", "count_or_runs", "p50_or_tokens", "p95", "p99"}); err != nil {
+		return err
+	}
+	for _, phase := range report.Phases {
+		if err := w.Write([]string{
+			"phase", phase.Name,
+			strconv.Itoa(phase.Count),
+			phase.P50.String(), phase.P95.String(), phase.P99.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, agent := range report.Agents {
+		if err := w.Write([]string{
+			"agent", agent.Name,
+			strconv.Itoa(agent.Runs),
+			strconv.Itoa(agent.TotalTokens), "", "",
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r CSVReporter) writer() io.Writer {
+	if r.Writer != nil {
+		return r.Writer
+	}
+	return os.Stdout
+}
+
+// reporterFor resolves a Spec.Output name ("json", "text", "csv") to a
+// Reporter writing to os.Stdout. An unrecognized or empty name falls back
+// to TextTableReporter, the most useful default for a terminal.
+func reporterFor(output string) Reporter {
+	switch output {
+	case "json":
+		return JSONReporter{}
+	case "csv":
+		return CSVReporter{}
+	default:
+		return TextTableReporter{}
+	}
+}
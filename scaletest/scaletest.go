@@ -0,0 +1,282 @@
+// Package scaletest is a scriptable load-test runner for breeze
+// TeamCollaborations: a JSON/YAML Spec describes what to run and how hard,
+// Runner.Run executes it under that concurrency, and a Reporter renders the
+// aggregated latency/token/error picture. It turns
+// TeamCollaboration.OnPhaseComplete/OnAgentResponse - so far just
+// progress-reporting hooks - into first-class observability for
+// characterizing a collaboration's behavior across LLM providers.
+package scaletest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/user/breeze"
+)
+
+// Spec describes one scale test run: what collaboration to execute, how
+// many times, and how hard. It's the JSON/YAML shape a user hands the
+// harness, e.g.:
+//
+//	{ "collaboration": "collab.yaml", "input": "build a web scraper",
+//	  "runs": 200, "concurrency": 20, "timeout": "5m", "output": "json" }
+type Spec struct {
+	// Collaboration is a path to a breeze.CollaborationSpec file, or the
+	// spec's raw YAML/JSON content inline.
+	Collaboration string `json:"collaboration" yaml:"collaboration"`
+	// Input is the initial prompt passed to TeamCollaboration.Run on every
+	// run.
+	Input string `json:"input" yaml:"input"`
+	// Runs is how many times the collaboration is executed in total.
+	Runs int `json:"runs" yaml:"runs"`
+	// Concurrency bounds how many runs execute at once. <= 0 means
+	// unbounded (one goroutine per run).
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Timeout is a per-run budget (e.g. "5m"), parsed via
+	// time.ParseDuration. A zero value means no per-run timeout.
+	Timeout string `json:"timeout" yaml:"timeout"`
+	// Output names the Reporter to use when Runner.Run isn't given one
+	// explicitly: "json", "text", or "csv".
+	Output string `json:"output" yaml:"output"`
+}
+
+// RunResult is what one TeamCollaboration.Run call produced: how long each
+// phase took, how many tokens (estimated) each agent produced, the
+// cumulative contribution size at the end of the run (a proxy for
+// SharedKnowledge growth), and any error.
+type RunResult struct {
+	PhaseDurations      map[string]time.Duration
+	AgentTokens         map[string]int
+	SharedKnowledgeSize int
+	Err                 error
+}
+
+// Report is the aggregate of every RunResult in a scale test.
+type Report struct {
+	Runs      int             `json:"runs"`
+	Errors    int             `json:"errors"`
+	ErrorRate float64         `json:"error_rate"`
+	Phases    []PhaseStats    `json:"phases"`
+	Agents    []AgentStats    `json:"agents"`
+	// SharedKnowledgeHistogram buckets each run's final SharedKnowledgeSize
+	// (rounded down to the nearest 1KB) to the number of runs that landed
+	// there, showing how contribution volume grows across a pipeline.
+	SharedKnowledgeHistogram map[int]int `json:"shared_knowledge_histogram_kb"`
+}
+
+// PhaseStats summarizes one phase's wall-clock latency across every run
+// that reached it.
+type PhaseStats struct {
+	Name  string        `json:"name"`
+	Count int           `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+}
+
+// AgentStats summarizes one agent's estimated token output across every
+// run it participated in.
+type AgentStats struct {
+	Name        string `json:"name"`
+	Runs        int    `json:"runs"`
+	TotalTokens int    `json:"total_tokens"`
+}
+
+// Runner executes a Spec and produces a Report.
+type Runner struct {
+	Spec Spec
+	// Reporter, if set, has Run render the Report through it in addition to
+	// returning it. Nil means Run only returns the Report.
+	Reporter Reporter
+}
+
+// NewRunner creates a Runner for spec, defaulting its Reporter from
+// spec.Output.
+func NewRunner(spec Spec) *Runner {
+	return &Runner{Spec: spec, Reporter: reporterFor(spec.Output)}
+}
+
+// Run executes Spec.Runs TeamCollaboration runs at Spec.Concurrency,
+// aggregates them into a Report, renders it through r.Reporter if set, and
+// returns it.
+func (r *Runner) Run() (*Report, error) {
+	specData, err := loadCollaborationData(r.Spec.Collaboration)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeout time.Duration
+	if r.Spec.Timeout != "" {
+		timeout, err = time.ParseDuration(r.Spec.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("scaletest: invalid timeout %q: %w", r.Spec.Timeout, err)
+		}
+	}
+
+	runs := r.Spec.Runs
+	if runs <= 0 {
+		runs = 1
+	}
+	concurrency := r.Spec.Concurrency
+	if concurrency <= 0 || concurrency > runs {
+		concurrency = runs
+	}
+
+	results := make([]RunResult, runs)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[idx] = runOnce(specData, r.Spec.Input, timeout)
+		}(i)
+	}
+	wg.Wait()
+
+	report := aggregate(results)
+	if r.Reporter != nil {
+		if err := r.Reporter.Report(report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// loadCollaborationData resolves spec.Collaboration into raw spec bytes,
+// reading it as a file path if one exists, or treating it as inline
+// YAML/JSON content otherwise.
+func loadCollaborationData(collaboration string) ([]byte, error) {
+	if data, err := os.ReadFile(collaboration); err == nil {
+		return data, nil
+	}
+	return []byte(collaboration), nil
+}
+
+// runOnce parses a fresh *breeze.TeamCollaboration from specData (so
+// concurrent runs don't share SharedKnowledge state), wires up recording
+// hooks, and executes it once, honoring timeout if set.
+//
+// TeamCollaboration.Run has no cancellation point, so a timeout can't abort
+// an in-flight run; it only bounds how long runOnce waits before reporting
+// a timeout error, same as any goroutine-leak tradeoff of wrapping a
+// blocking call in a timeout.
+func runOnce(specData []byte, input string, timeout time.Duration) RunResult {
+	tc, err := breeze.LoadCollaborationSpecBytes(specData)
+	if err != nil {
+		return RunResult{Err: err}
+	}
+
+	result := RunResult{
+		PhaseDurations: make(map[string]time.Duration),
+		AgentTokens:    make(map[string]int),
+	}
+
+	var mu sync.Mutex
+	phaseStart := time.Now()
+	tc.OnPhaseComplete = func(phaseName string, phaseResults map[string]string) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.PhaseDurations[phaseName] = time.Since(phaseStart)
+		phaseStart = time.Now()
+	}
+	tc.OnAgentResponse = func(agentName, response string) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.AgentTokens[agentName] += len(response) / 4
+		result.SharedKnowledgeSize += len(response)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := tc.Run(input)
+		mu.Lock()
+		result.Err = err
+		mu.Unlock()
+	}()
+
+	if timeout <= 0 {
+		<-done
+		return result
+	}
+
+	select {
+	case <-done:
+		return result
+	case <-time.After(timeout):
+		mu.Lock()
+		result.Err = fmt.Errorf("scaletest: run exceeded timeout %s", timeout)
+		mu.Unlock()
+		return result
+	}
+}
+
+// aggregate folds every RunResult into a Report: per-phase latency
+// percentiles, per-agent token totals, the error rate, and the
+// SharedKnowledge-size histogram.
+func aggregate(results []RunResult) *Report {
+	report := &Report{
+		Runs:                     len(results),
+		SharedKnowledgeHistogram: make(map[int]int),
+	}
+
+	phaseDurations := make(map[string][]time.Duration)
+	agentRuns := make(map[string]int)
+	agentTokens := make(map[string]int)
+
+	for _, res := range results {
+		if res.Err != nil {
+			report.Errors++
+			continue
+		}
+		for phase, d := range res.PhaseDurations {
+			phaseDurations[phase] = append(phaseDurations[phase], d)
+		}
+		for agent, tokens := range res.AgentTokens {
+			agentRuns[agent]++
+			agentTokens[agent] += tokens
+		}
+		bucket := res.SharedKnowledgeSize / 1024
+		report.SharedKnowledgeHistogram[bucket]++
+	}
+	if report.Runs > 0 {
+		report.ErrorRate = float64(report.Errors) / float64(report.Runs)
+	}
+
+	for phase, durations := range phaseDurations {
+		report.Phases = append(report.Phases, PhaseStats{
+			Name:  phase,
+			Count: len(durations),
+			P50:   percentile(durations, 0.50),
+			P95:   percentile(durations, 0.95),
+			P99:   percentile(durations, 0.99),
+		})
+	}
+	sort.Slice(report.Phases, func(i, j int) bool { return report.Phases[i].Name < report.Phases[j].Name })
+
+	for agent, tokens := range agentTokens {
+		report.Agents = append(report.Agents, AgentStats{Name: agent, Runs: agentRuns[agent], TotalTokens: tokens})
+	}
+	sort.Slice(report.Agents, func(i, j int) bool { return report.Agents[i].Name < report.Agents[j].Name })
+
+	return report
+}
+
+// percentile returns the p-th percentile (0 <= p <= 1) of durations using
+// nearest-rank, sorting a copy so the caller's slice order is untouched.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(p*float64(len(sorted)-1) + 0.5)
+	return sorted[rank]
+}
@@ -0,0 +1,105 @@
+package scaletest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		300 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+	if p := percentile(durations, 0.50); p != 300*time.Millisecond {
+		t.Errorf("Expected p50 300ms, got %s", p)
+	}
+	if p := percentile(nil, 0.50); p != 0 {
+		t.Errorf("Expected percentile of an empty slice to be 0, got %s", p)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	results := []RunResult{
+		{
+			PhaseDurations:      map[string]time.Duration{"Requirements": 100 * time.Millisecond},
+			AgentTokens:         map[string]int{"Alice": 50},
+			SharedKnowledgeSize: 2048,
+		},
+		{
+			PhaseDurations:      map[string]time.Duration{"Requirements": 200 * time.Millisecond},
+			AgentTokens:         map[string]int{"Alice": 70},
+			SharedKnowledgeSize: 2048,
+		},
+		{Err: errBoom},
+	}
+
+	report := aggregate(results)
+	if report.Runs != 3 || report.Errors != 1 {
+		t.Fatalf("Expected 3 runs / 1 error, got %d runs / %d errors", report.Runs, report.Errors)
+	}
+	if report.ErrorRate < 0.333 || report.ErrorRate > 0.334 {
+		t.Errorf("Expected error rate ~0.333, got %f", report.ErrorRate)
+	}
+	if len(report.Phases) != 1 || report.Phases[0].Count != 2 {
+		t.Fatalf("Expected 1 phase with 2 samples, got %+v", report.Phases)
+	}
+	if len(report.Agents) != 1 || report.Agents[0].TotalTokens != 120 {
+		t.Fatalf("Expected Alice with 120 total tokens, got %+v", report.Agents)
+	}
+	if report.SharedKnowledgeHistogram[2] != 2 {
+		t.Errorf("Expected 2 runs in the 2KB bucket, got %+v", report.SharedKnowledgeHistogram)
+	}
+}
+
+func TestReporterFor(t *testing.T) {
+	if _, ok := reporterFor("json").(JSONReporter); !ok {
+		t.Error("Expected reporterFor(\"json\") to return a JSONReporter")
+	}
+	if _, ok := reporterFor("csv").(CSVReporter); !ok {
+		t.Error("Expected reporterFor(\"csv\") to return a CSVReporter")
+	}
+	if _, ok := reporterFor("nonsense").(TextTableReporter); !ok {
+		t.Error("Expected reporterFor of an unknown output to fall back to TextTableReporter")
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf strings.Builder
+	report := &Report{Runs: 1, Phases: []PhaseStats{{Name: "Requirements", Count: 1}}}
+	if err := (JSONReporter{Writer: &buf}).Report(report); err != nil {
+		t.Fatalf("JSONReporter.Report failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"Requirements\"") {
+		t.Errorf("Expected JSON output to contain the phase name, got %s", buf.String())
+	}
+}
+
+func TestRenderPrometheusMetrics(t *testing.T) {
+	report := &Report{
+		Runs:   2,
+		Errors: 1,
+		Phases: []PhaseStats{{Name: "Requirements", P50: 100 * time.Millisecond}},
+		Agents: []AgentStats{{Name: "Alice", TotalTokens: 42}},
+	}
+	out := renderPrometheusMetrics(report)
+	for _, want := range []string{
+		"breeze_scaletest_runs_total 2",
+		"breeze_scaletest_errors_total 1",
+		`phase="Requirements"`,
+		`agent="Alice"} 42`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected metrics output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+var errBoom = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,118 @@
+package breeze
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithJSONSchema constrains decoding to JSON matching schema (a JSON Schema
+// document), forwarded as Ollama's native "format" field. Models backed by
+// a runner that enforces it (Ollama's current default) will only emit
+// tokens that keep the output valid against schema. See AIInto for a typed
+// helper that builds schema from a Go struct automatically.
+func WithJSONSchema(schema string) Option {
+	return func(opts *RequestOptions) {
+		opts.JSONSchema = schema
+	}
+}
+
+// WithGrammar constrains decoding to a GBNF grammar, forwarded to backends
+// that support grammar-constrained decoding (e.g. LlamaCppBackend's
+// --grammar flag). Ollama's HTTP API has no GBNF equivalent; use
+// WithJSONSchema for Ollama-backed requests.
+func WithGrammar(gbnf string) Option {
+	return func(opts *RequestOptions) {
+		opts.Grammar = gbnf
+	}
+}
+
+// ollamaFormat parses schema as JSON for Ollama's "format" field (which
+// takes a JSON Schema object, not a string). If schema isn't valid JSON
+// (e.g. a caller passed the literal "json"), it's forwarded as-is.
+func ollamaFormat(schema string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return schema
+	}
+	return parsed
+}
+
+// AIInto asks the model to produce JSON shaped like T, constraining
+// decoding with a JSON schema reflected from T's fields (see
+// reflectJSONSchema), unmarshals the reply into T, and retries once with a
+// repair prompt (showing the model its own malformed reply and the parse
+// error) if unmarshaling fails.
+func AIInto[T any](prompt string, opts ...Option) (T, error) {
+	var result T
+	opts = append(opts, WithJSONSchema(reflectJSONSchema(result)))
+
+	text := AI(prompt, opts...)
+	if err := json.Unmarshal([]byte(extractJSONObject(text)), &result); err == nil {
+		return result, nil
+	} else {
+		repairPrompt := fmt.Sprintf("Your previous reply could not be parsed as JSON (%v):\n\n%s\n\nReply again with ONLY a single JSON object matching the required schema.", err, text)
+		text = AI(repairPrompt, opts...)
+		if err2 := json.Unmarshal([]byte(extractJSONObject(text)), &result); err2 == nil {
+			return result, nil
+		}
+		return result, fmt.Errorf("AIInto: no valid JSON after retry: %w", err)
+	}
+}
+
+// reflectJSONSchema builds a JSON Schema document describing v's type,
+// recursing into structs, slices, and pointers, for use with
+// WithJSONSchema/AIInto.
+func reflectJSONSchema(v interface{}) string {
+	data, _ := json.Marshal(reflectTypeSchema(reflect.TypeOf(v)))
+	return string(data)
+}
+
+// reflectTypeSchema is the recursive step behind reflectJSONSchema.
+func reflectTypeSchema(t reflect.Type) map[string]interface{} {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue // unexported
+			}
+			name := f.Tag.Get("json")
+			if idx := strings.Index(name, ","); idx != -1 {
+				name = name[:idx]
+			}
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			properties[name] = reflectTypeSchema(f.Type)
+			required = append(required, name)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": reflectTypeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// handleChatCompletions implements POST /v1/chat/completions, translating
+// the request into an Ollama /api/chat call and the reply back into OpenAI
+// JSON (or an SSE `data: ` stream when Stream is set).
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req ChatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "invalid_request_error")
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "messages is required", "invalid_request_error")
+		return
+	}
+
+	base := s.baseURLFor(req.Model)
+	resp, err := ollamaChat(r.Context(), base, req.Model, req.Messages, req.Temperature, req.Stream)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error(), "upstream_error")
+		return
+	}
+	defer resp.Body.Close()
+
+	respID := id("chatcmpl")
+	created := time.Now().Unix()
+
+	if !req.Stream {
+		var chunk ollamaChatChunk
+		if err := json.NewDecoder(resp.Body).Decode(&chunk); err != nil {
+			writeError(w, http.StatusBadGateway, "decode ollama response: "+err.Error(), "upstream_error")
+			return
+		}
+		writeJSON(w, http.StatusOK, ChatCompletionResponse{
+			ID:      respID,
+			Object:  "chat.completion",
+			Created: created,
+			Model:   req.Model,
+			Choices: []ChatCompletionChoice{{
+				Index:        0,
+				Message:      ChatMessage{Role: "assistant", Content: chunk.Message.Content},
+				FinishReason: "stop",
+			}},
+			Usage: usageFrom(chunk.PromptEvalCount, chunk.EvalCount),
+		})
+		return
+	}
+
+	streamSSE(w, func(send func(v interface{})) {
+		decoder := json.NewDecoder(resp.Body)
+		first := true
+		for {
+			var chunk ollamaChatChunk
+			if err := decoder.Decode(&chunk); err != nil {
+				break
+			}
+			delta := ChatCompletionChunkDelta{Content: chunk.Message.Content}
+			if first {
+				delta.Role = "assistant"
+				first = false
+			}
+			send(ChatCompletionChunk{
+				ID:      respID,
+				Object:  "chat.completion.chunk",
+				Created: created,
+				Model:   req.Model,
+				Choices: []ChatCompletionChunkChoice{{Index: 0, Delta: delta, FinishReason: nil}},
+			})
+			if chunk.Done {
+				send(ChatCompletionChunk{
+					ID:      respID,
+					Object:  "chat.completion.chunk",
+					Created: created,
+					Model:   req.Model,
+					Choices: []ChatCompletionChunkChoice{{Index: 0, Delta: ChatCompletionChunkDelta{}, FinishReason: strPtr("stop")}},
+				})
+				break
+			}
+		}
+	})
+}
+
+// handleCompletions implements POST /v1/completions, the legacy raw-prompt
+// completion API, on top of Ollama's /api/generate.
+func (s *Server) handleCompletions(w http.ResponseWriter, r *http.Request) {
+	var req CompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "invalid_request_error")
+		return
+	}
+	if req.Prompt == "" {
+		writeError(w, http.StatusBadRequest, "prompt is required", "invalid_request_error")
+		return
+	}
+
+	base := s.baseURLFor(req.Model)
+	resp, err := ollamaGenerate(r.Context(), base, req.Model, req.Prompt, req.Temperature, req.Stream)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error(), "upstream_error")
+		return
+	}
+	defer resp.Body.Close()
+
+	respID := id("cmpl")
+	created := time.Now().Unix()
+
+	if !req.Stream {
+		var chunk ollamaGenerateChunk
+		if err := json.NewDecoder(resp.Body).Decode(&chunk); err != nil {
+			writeError(w, http.StatusBadGateway, "decode ollama response: "+err.Error(), "upstream_error")
+			return
+		}
+		writeJSON(w, http.StatusOK, CompletionResponse{
+			ID:      respID,
+			Object:  "text_completion",
+			Created: created,
+			Model:   req.Model,
+			Choices: []CompletionChoice{{Index: 0, Text: chunk.Response, FinishReason: "stop"}},
+			Usage:   usageFrom(chunk.PromptEvalCount, chunk.EvalCount),
+		})
+		return
+	}
+
+	streamSSE(w, func(send func(v interface{})) {
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var chunk ollamaGenerateChunk
+			if err := decoder.Decode(&chunk); err != nil {
+				break
+			}
+			finish := (*string)(nil)
+			if chunk.Done {
+				finish = strPtr("stop")
+			}
+			send(struct {
+				ID      string             `json:"id"`
+				Object  string             `json:"object"`
+				Created int64              `json:"created"`
+				Model   string             `json:"model"`
+				Choices []CompletionChoice `json:"choices"`
+			}{
+				ID:      respID,
+				Object:  "text_completion",
+				Created: created,
+				Model:   req.Model,
+				Choices: []CompletionChoice{{Index: 0, Text: chunk.Response, FinishReason: finishReasonOr(finish)}},
+			})
+			if chunk.Done {
+				break
+			}
+		}
+	})
+}
+
+// handleEmbeddings implements POST /v1/embeddings on top of Ollama's
+// /api/embeddings, accepting either a single string or a list of strings as
+// Input.
+func (s *Server) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var req EmbeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "invalid_request_error")
+		return
+	}
+
+	var inputs []string
+	switch v := req.Input.(type) {
+	case string:
+		inputs = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "input must be a string or list of strings", "invalid_request_error")
+				return
+			}
+			inputs = append(inputs, s)
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "input is required", "invalid_request_error")
+		return
+	}
+
+	base := s.baseURLFor(req.Model)
+	data := make([]EmbeddingData, len(inputs))
+	promptTokens := 0
+	for i, input := range inputs {
+		embedding, err := ollamaEmbed(r.Context(), base, req.Model, input)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error(), "upstream_error")
+			return
+		}
+		data[i] = EmbeddingData{Object: "embedding", Embedding: embedding, Index: i}
+		promptTokens += len(input) / 4 // rough token estimate; Ollama's embeddings API reports no usage
+	}
+
+	writeJSON(w, http.StatusOK, EmbeddingResponse{
+		Object: "list",
+		Data:   data,
+		Model:  req.Model,
+		Usage:  Usage{PromptTokens: promptTokens, TotalTokens: promptTokens},
+	})
+}
+
+// handleModels implements GET /v1/models on top of Ollama's /api/tags.
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+	models, err := ollamaTags(r.Context(), s.cfg.OllamaBaseURL)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error(), "upstream_error")
+		return
+	}
+
+	data := make([]ModelInfo, len(models))
+	for i, m := range models {
+		modified, _ := time.Parse(time.RFC3339, m.ModifiedAt)
+		data[i] = ModelInfo{ID: m.Name, Object: "model", Created: modified.Unix(), OwnedBy: "ollama"}
+	}
+
+	writeJSON(w, http.StatusOK, ModelsResponse{Object: "list", Data: data})
+}
+
+// usageFrom builds a Usage from Ollama's prompt_eval_count/eval_count.
+func usageFrom(promptEvalCount, evalCount int) Usage {
+	return Usage{
+		PromptTokens:     promptEvalCount,
+		CompletionTokens: evalCount,
+		TotalTokens:      promptEvalCount + evalCount,
+	}
+}
+
+func finishReasonOr(finish *string) string {
+	if finish == nil {
+		return ""
+	}
+	return *finish
+}
+
+// streamSSE sends the response as a stream of `data: <json>\n\n` events,
+// terminated by `data: [DONE]\n\n`, flushing after each one.
+func streamSSE(w http.ResponseWriter, produce func(send func(v interface{}))) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, canFlush := w.(http.Flusher)
+	bw := bufio.NewWriter(w)
+
+	send := func(v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(bw, "data: %s\n\n", data)
+		bw.Flush()
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+
+	produce(send)
+
+	fmt.Fprint(bw, "data: [DONE]\n\n")
+	bw.Flush()
+	if canFlush {
+		flusher.Flush()
+	}
+}
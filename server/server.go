@@ -0,0 +1,300 @@
+// Package server exposes Breeze over HTTP using the OpenAI REST API shape
+// (/v1/chat/completions, /v1/completions, /v1/embeddings, /v1/models), so
+// existing OpenAI SDKs, LangChain clients, and chat UIs can point at a local
+// Ollama-backed Breeze without any code changes.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Config holds the options governing a Server, set via ServeOption
+// functions passed to New or Serve.
+type Config struct {
+	// APIKey, if set, is required as a Bearer token on every request. Empty
+	// means no auth.
+	APIKey string
+	// AllowOrigins, if non-empty, enables CORS for the listed origins (or
+	// "*" for any origin).
+	AllowOrigins []string
+	// OllamaBaseURL is the default Ollama daemon requests are routed to.
+	// Defaults to http://localhost:11434.
+	OllamaBaseURL string
+	// ModelBackends maps a requested model name to the Ollama base URL that
+	// should serve it, for routing different models to different Ollama
+	// instances. A model not present here uses OllamaBaseURL.
+	ModelBackends map[string]string
+}
+
+// ServeOption is a functional option for configuring a Server, mirroring
+// breeze.Option's pattern.
+type ServeOption func(*Config)
+
+// WithAPIKey requires Authorization: Bearer <key> on every request.
+func WithAPIKey(key string) ServeOption {
+	return func(c *Config) {
+		c.APIKey = key
+	}
+}
+
+// WithCORS enables CORS for the given origins ("*" for any origin).
+func WithCORS(origins ...string) ServeOption {
+	return func(c *Config) {
+		c.AllowOrigins = append(c.AllowOrigins, origins...)
+	}
+}
+
+// WithOllamaBaseURL overrides the default Ollama daemon address
+// (http://localhost:11434) that requests are routed to.
+func WithOllamaBaseURL(url string) ServeOption {
+	return func(c *Config) {
+		c.OllamaBaseURL = url
+	}
+}
+
+// WithModelBackend routes requests for model to a specific Ollama base URL,
+// e.g. to split "gpt-oss" and "codellama" across two Ollama instances.
+func WithModelBackend(model, baseURL string) ServeOption {
+	return func(c *Config) {
+		if c.ModelBackends == nil {
+			c.ModelBackends = make(map[string]string)
+		}
+		c.ModelBackends[model] = baseURL
+	}
+}
+
+// Server is an OpenAI-compatible HTTP server backed by one or more Ollama
+// daemons.
+type Server struct {
+	cfg Config
+}
+
+// New builds a Server from opts without starting it; use Handler to mount
+// it on your own http.Server, or call Serve to both build and listen.
+func New(opts ...ServeOption) *Server {
+	cfg := Config{OllamaBaseURL: "http://localhost:11434"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Server{cfg: cfg}
+}
+
+// Serve builds a Server from opts and blocks serving it on addr, in the
+// style of http.ListenAndServe.
+func Serve(addr string, opts ...ServeOption) error {
+	s := New(opts...)
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+// Handler returns the Server's routes wrapped in its auth/CORS middleware,
+// suitable for mounting on an *http.Server or httptest.Server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("/v1/completions", s.handleCompletions)
+	mux.HandleFunc("/v1/embeddings", s.handleEmbeddings)
+	mux.HandleFunc("/v1/models", s.handleModels)
+	return s.withMiddleware(mux)
+}
+
+// withMiddleware wraps next with CORS handling and, if an APIKey is
+// configured, Bearer-token auth.
+func (s *Server) withMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(s.cfg.AllowOrigins) > 0 {
+			s.applyCORS(w, r)
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		if s.cfg.APIKey != "" {
+			auth := r.Header.Get("Authorization")
+			if auth != "Bearer "+s.cfg.APIKey {
+				writeError(w, http.StatusUnauthorized, "invalid API key", "invalid_request_error")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) applyCORS(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	for _, allowed := range s.cfg.AllowOrigins {
+		if allowed == "*" || allowed == origin {
+			w.Header().Set("Access-Control-Allow-Origin", allowed)
+			break
+		}
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+}
+
+// baseURLFor returns the Ollama base URL configured for model via
+// ModelBackends, falling back to OllamaBaseURL.
+func (s *Server) baseURLFor(model string) string {
+	if url, ok := s.cfg.ModelBackends[model]; ok {
+		return url
+	}
+	return s.cfg.OllamaBaseURL
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message, typ string) {
+	writeJSON(w, status, errorResponse{Error: errorDetail{Message: message, Type: typ}})
+}
+
+// id generates a chatcmpl/cmpl-style response ID from the current time,
+// since these just need to be unique, not cryptographically random.
+func id(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+}
+
+// ollamaChat posts messages to base/api/chat and returns the raw decoded
+// response, giving callers access to Ollama's prompt_eval_count/eval_count
+// alongside the reply content.
+func ollamaChat(ctx context.Context, base, model string, messages []ChatMessage, temp *float64, stream bool) (*http.Response, error) {
+	ollamaMessages := make([]map[string]string, len(messages))
+	for i, m := range messages {
+		ollamaMessages[i] = map[string]string{"role": m.Role, "content": m.Content}
+	}
+
+	req := map[string]interface{}{
+		"model":    model,
+		"messages": ollamaMessages,
+		"stream":   stream,
+	}
+	if temp != nil {
+		req["options"] = map[string]interface{}{"temperature": *temp}
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/api/chat", bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	return http.DefaultClient.Do(httpReq)
+}
+
+// ollamaChatChunk is the subset of an Ollama /api/chat streaming or final
+// response this package reads.
+type ollamaChatChunk struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool `json:"done"`
+	PromptEvalCount int  `json:"prompt_eval_count"`
+	EvalCount       int  `json:"eval_count"`
+}
+
+// ollamaGenerate posts prompt to base/api/generate, the raw-prompt endpoint
+// backing the legacy /v1/completions API.
+func ollamaGenerate(ctx context.Context, base, model, prompt string, temp *float64, stream bool) (*http.Response, error) {
+	req := map[string]interface{}{
+		"model":  model,
+		"prompt": prompt,
+		"stream": stream,
+	}
+	if temp != nil {
+		req["options"] = map[string]interface{}{"temperature": *temp}
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/api/generate", bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	return http.DefaultClient.Do(httpReq)
+}
+
+// ollamaGenerateChunk is the subset of an Ollama /api/generate streaming or
+// final response this package reads.
+type ollamaGenerateChunk struct {
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// ollamaEmbed posts a single input string to base/api/embeddings.
+func ollamaEmbed(ctx context.Context, base, model, input string) ([]float64, error) {
+	req := map[string]interface{}{"model": model, "prompt": input}
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/api/embeddings", bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embedding []float64 `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode embeddings response: %w", err)
+	}
+	return result.Embedding, nil
+}
+
+// ollamaModel is the subset of an Ollama /api/tags model entry this package
+// reads.
+type ollamaModel struct {
+	Name       string `json:"name"`
+	ModifiedAt string `json:"modified_at"`
+}
+
+// ollamaTags fetches the models known to base.
+func ollamaTags(ctx context.Context, base string) ([]ollamaModel, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Models []ollamaModel `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode tags response: %w", err)
+	}
+	return result.Models, nil
+}
+
+func strPtr(s string) *string { return &s }
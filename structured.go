@@ -0,0 +1,71 @@
+package breeze
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructuredOutput asks the model to produce a JSON object shaped like T,
+// parses the response with encoding/json, and retries with a repair prompt
+// (showing the model its own malformed reply) up to maxRetries times before
+// giving up. Callers that have a heuristic fallback should treat a non-nil
+// error as "use the fallback" rather than a fatal condition.
+func StructuredOutput[T any](prompt string, maxRetries int, opts ...Option) (T, error) {
+	var result T
+	schemaPrompt := prompt + "\n\n" + jsonSchemaInstruction(result)
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		text := AI(schemaPrompt, opts...)
+		if err := json.Unmarshal([]byte(extractJSONObject(text)), &result); err == nil {
+			return result, nil
+		} else {
+			lastErr = err
+			schemaPrompt = fmt.Sprintf("Your previous reply could not be parsed as JSON (%v):\n\n%s\n\nReply again with ONLY a single JSON object matching: %s", err, text, jsonSchemaInstruction(result))
+		}
+	}
+
+	return result, fmt.Errorf("structured output: no valid JSON after %d attempt(s): %w", maxRetries+1, lastErr)
+}
+
+// jsonSchemaInstruction describes v's JSON shape from its struct tags, so
+// the model can be told exactly what fields to return without maintaining a
+// separate schema definition alongside each call site.
+func jsonSchemaInstruction(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "Respond with ONLY valid JSON, no markdown fences or extra prose."
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("json")
+		if idx := strings.Index(name, ","); idx != -1 {
+			name = name[:idx]
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, fmt.Sprintf("%q: %s", name, f.Type.String()))
+	}
+
+	return fmt.Sprintf("Respond with ONLY a single JSON object (no markdown fences, no prose before or after) with exactly these fields: {%s}", strings.Join(fields, ", "))
+}
+
+// extractJSONObject trims any surrounding prose or markdown fences a model
+// adds despite instructions, returning the substring from the first '{' to
+// the last '}'.
+func extractJSONObject(s string) string {
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start == -1 || end == -1 || end < start {
+		return s
+	}
+	return s[start : end+1]
+}
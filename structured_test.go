@@ -0,0 +1,28 @@
+package breeze
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractJSONObject_StripsSurroundingProse(t *testing.T) {
+	got := extractJSONObject("Sure, here you go:\n```json\n{\"score\": 8}\n```\nLet me know if you need more.")
+	if got != `{"score": 8}` {
+		t.Errorf("expected the JSON object to be isolated, got %q", got)
+	}
+}
+
+func TestJSONSchemaInstruction_ListsTaggedFields(t *testing.T) {
+	type review struct {
+		Score    int      `json:"score"`
+		Comments string   `json:"comments"`
+		Tags     []string `json:"tags,omitempty"`
+	}
+
+	instruction := jsonSchemaInstruction(review{})
+	for _, field := range []string{`"score"`, `"comments"`, `"tags"`} {
+		if !strings.Contains(instruction, field) {
+			t.Errorf("expected schema instruction to mention %s, got %q", field, instruction)
+		}
+	}
+}
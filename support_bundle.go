@@ -0,0 +1,301 @@
+package breeze
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallRecord is one prompt/response pair a policyAI-dispatched
+// CollaborationMethod call produced, with enough metadata
+// (ExportSupportBundle's consumer, runtime reflection's func name) to
+// reconstruct a run's trace without re-invoking the model.
+type CallRecord struct {
+	PhaseName string        `json:"phase"`
+	AgentName string        `json:"agent"`
+	Object    string        `json:"object"`
+	Prompt    string        `json:"prompt"`
+	Response  string        `json:"response"`
+	Duration  time.Duration `json:"duration_ns"`
+	// Tokens is a rough len/4 estimate summed over Prompt and Response, the
+	// same convention used elsewhere in this package.
+	Tokens int `json:"tokens"`
+}
+
+// CallRecorder accumulates CallRecords across a Collaboration.Run, safe for
+// concurrent appends from the goroutines Parallel/PeerReview/Consensus/
+// CoderStrategy spawn. Assign one to Collaboration.Recorder before Run to
+// capture a trace for ExportSupportBundle.
+type CallRecorder struct {
+	mu      sync.Mutex
+	records []CallRecord
+}
+
+// NewCallRecorder returns an empty CallRecorder.
+func NewCallRecorder() *CallRecorder {
+	return &CallRecorder{}
+}
+
+func (r *CallRecorder) record(rec CallRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// Records returns a snapshot copy of every CallRecord captured so far.
+func (r *CallRecorder) Records() []CallRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]CallRecord, len(r.records))
+	copy(out, r.records)
+	return out
+}
+
+// supportManifest is written as manifest.json inside a support bundle,
+// describing the rest of the zip's contents so breeze support inspect can
+// render a summary without re-parsing every file.
+type supportManifest struct {
+	GeneratedAt     string            `json:"generated_at"`
+	Agents          []string          `json:"agents"`
+	Phases          []string          `json:"phases"`
+	PhaseMethods    map[string]string `json:"phase_methods"`
+	CallCount       int               `json:"call_count"`
+	TotalTokens     int               `json:"total_tokens"`
+	TotalDurationNS int64             `json:"total_duration_ns"`
+	Artifacts       []string          `json:"artifacts"`
+}
+
+// ExportSupportBundle zips a full postmortem of a completed run to path: the
+// agents list, every phase alongside its CollaborationMethod name (best
+// effort - see methodName), every CallRecord collab.Recorder captured
+// (prompt/response, token/latency stats, including DebateStyle's rounds and
+// PeerReview's reviews, since each is its own CallRecord), the final
+// SharedKnowledge map, and any files CoderStrategy stashed via
+// DecodeCoderArtifacts. Every string written (prompts, responses,
+// SharedKnowledge values) is run through collab.RedactPatterns first. A nil
+// collab.Recorder produces a bundle with an empty calls.jsonl rather than an
+// error, since Recorder is opt-in.
+func (c *Collaboration) ExportSupportBundle(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("export support bundle: create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	defer zw.Close()
+
+	var calls []CallRecord
+	if c.Recorder != nil {
+		calls = c.Recorder.Records()
+	}
+
+	agentNames := make([]string, len(c.Agents))
+	for i, agent := range c.Agents {
+		agentNames[i] = agent.Name
+	}
+
+	phaseNames := make([]string, len(c.Phases))
+	phaseMethods := make(map[string]string, len(c.Phases))
+	for i, phase := range c.Phases {
+		phaseNames[i] = phase.Name
+		if phase.Method != nil {
+			phaseMethods[phase.Name] = methodName(phase.Method)
+		}
+	}
+
+	artifacts, err := DecodeCoderArtifacts(c.SharedKnowledge)
+	if err != nil {
+		artifacts = make(map[string]string)
+	}
+	artifactNames := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		artifactNames = append(artifactNames, name)
+	}
+	sort.Strings(artifactNames)
+
+	var totalTokens int
+	var totalDuration time.Duration
+	for _, call := range calls {
+		totalTokens += call.Tokens
+		totalDuration += call.Duration
+	}
+
+	manifest := supportManifest{
+		GeneratedAt:     time.Now().Format(time.RFC3339),
+		Agents:          agentNames,
+		Phases:          phaseNames,
+		PhaseMethods:    phaseMethods,
+		CallCount:       len(calls),
+		TotalTokens:     totalTokens,
+		TotalDurationNS: int64(totalDuration),
+		Artifacts:       artifactNames,
+	}
+	if err := writeJSONEntry(zw, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	sharedKnowledge := make(map[string]string, len(c.SharedKnowledge))
+	for key, value := range c.SharedKnowledge {
+		sharedKnowledge[key] = redactText(c.RedactPatterns, value)
+	}
+	if err := writeJSONEntry(zw, "shared_knowledge.json", sharedKnowledge); err != nil {
+		return err
+	}
+
+	callsWriter, err := zw.Create("calls.jsonl")
+	if err != nil {
+		return fmt.Errorf("export support bundle: create calls.jsonl: %w", err)
+	}
+	for _, call := range calls {
+		call.Prompt = redactText(c.RedactPatterns, call.Prompt)
+		call.Response = redactText(c.RedactPatterns, call.Response)
+		data, err := json.Marshal(call)
+		if err != nil {
+			return fmt.Errorf("export support bundle: marshal call for %s/%s: %w", call.PhaseName, call.AgentName, err)
+		}
+		if _, err := callsWriter.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("export support bundle: write calls.jsonl: %w", err)
+		}
+	}
+
+	for _, name := range artifactNames {
+		writer, err := zw.Create("artifacts/" + name)
+		if err != nil {
+			return fmt.Errorf("export support bundle: create artifacts/%s: %w", name, err)
+		}
+		if _, err := io.WriteString(writer, redactText(c.RedactPatterns, artifacts[name])); err != nil {
+			return fmt.Errorf("export support bundle: write artifacts/%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeJSONEntry marshals v as indented JSON into a new zip entry named
+// name.
+func writeJSONEntry(zw *zip.Writer, name string, v interface{}) error {
+	writer, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("export support bundle: create %s: %w", name, err)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("export support bundle: marshal %s: %w", name, err)
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// redactText replaces every match of every pattern in patterns with
+// "[REDACTED]". A nil/empty patterns leaves text unchanged.
+func redactText(patterns []*regexp.Regexp, text string) string {
+	for _, pattern := range patterns {
+		text = pattern.ReplaceAllString(text, "[REDACTED]")
+	}
+	return text
+}
+
+// InspectSupportBundle reads path (as written by ExportSupportBundle) and
+// renders a tabular summary of its manifest plus each call's timing/token
+// cost, for the `breeze support inspect` CLI command.
+func InspectSupportBundle(path string) (string, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return "", fmt.Errorf("inspect support bundle: open %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	var manifest supportManifest
+	if err := readJSONEntry(&reader.Reader, "manifest.json", &manifest); err != nil {
+		return "", err
+	}
+
+	var calls []CallRecord
+	if file := findEntry(&reader.Reader, "calls.jsonl"); file != nil {
+		rc, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("inspect support bundle: open calls.jsonl: %w", err)
+		}
+		defer rc.Close()
+		decoder := json.NewDecoder(rc)
+		for decoder.More() {
+			var call CallRecord
+			if err := decoder.Decode(&call); err != nil {
+				return "", fmt.Errorf("inspect support bundle: decode calls.jsonl: %w", err)
+			}
+			calls = append(calls, call)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Support bundle: %s\n", path)
+	fmt.Fprintf(&b, "Generated: %s\n", manifest.GeneratedAt)
+	fmt.Fprintf(&b, "Agents: %s\n", strings.Join(manifest.Agents, ", "))
+	fmt.Fprintf(&b, "Phases: %d (%d artifacts)\n\n", len(manifest.Phases), len(manifest.Artifacts))
+
+	fmt.Fprintf(&b, "%-20s %-15s %-20s %10s %10s\n", "PHASE", "AGENT", "OBJECT", "TOKENS", "DURATION")
+	for _, call := range calls {
+		fmt.Fprintf(&b, "%-20s %-15s %-20s %10d %10s\n", call.PhaseName, call.AgentName, call.Object, call.Tokens, call.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(&b, "\n%d calls, ~%d tokens, %s total\n", manifest.CallCount, manifest.TotalTokens, time.Duration(manifest.TotalDurationNS).Round(time.Millisecond))
+
+	return b.String(), nil
+}
+
+// readJSONEntry finds name in r and JSON-decodes it into v.
+func readJSONEntry(r *zip.Reader, name string, v interface{}) error {
+	file := findEntry(r, name)
+	if file == nil {
+		return fmt.Errorf("inspect support bundle: %s not found in bundle", name)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("inspect support bundle: open %s: %w", name, err)
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("inspect support bundle: decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// findEntry returns the *zip.File named name in r, or nil if absent.
+func findEntry(r *zip.Reader, name string) *zip.File {
+	for _, file := range r.File {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
+// methodName best-effort names a CollaborationMethod for manifest.json by
+// reading back the runtime func name of the closure Sequential/Parallel/
+// PeerReview/Consensus/DebateStyle/CoderStrategy return (e.g.
+// "github.com/user/breeze.Consensus.func1"), trimming it down to just
+// "Consensus". A method built from an anonymous func literal (not one of
+// the named constructors) reports its raw runtime name instead of failing.
+func methodName(m CollaborationMethod) string {
+	name := runtime.FuncForPC(reflect.ValueOf(m).Pointer()).Name()
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return name
+	}
+	// Constructors like Sequential/Consensus/CoderStrategy return a closure,
+	// whose runtime name is "<pkg>.<Constructor>.funcN" - parts[len-2] is
+	// the constructor name we want; parts[len-1] ("funcN") is discarded.
+	if strings.HasPrefix(parts[len(parts)-1], "func") {
+		return parts[len(parts)-2]
+	}
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,121 @@
+package breeze
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestMethodName(t *testing.T) {
+	cases := []struct {
+		method CollaborationMethod
+		want   string
+	}{
+		{Sequential(), "Sequential"},
+		{Parallel(2), "Parallel"},
+		{Consensus(0), "Consensus"},
+		{CoderStrategy(TopDown), "CoderStrategy"},
+	}
+	for _, c := range cases {
+		if got := methodName(c.method); got != c.want {
+			t.Errorf("methodName(%T) = %q, want %q", c.method, got, c.want)
+		}
+	}
+}
+
+func TestExportSupportBundle_RoundTrip(t *testing.T) {
+	collab := &Collaboration{
+		Agents:          []Agent{{Name: "Alice", Role: "Dev"}},
+		Phases:          []Phase{{Name: "Design", Method: Sequential(), Produces: []string{"Alice"}}},
+		SharedKnowledge: map[string]string{"Alice": "api-key=sk-live-deadbeef is my secret"},
+		Recorder:        NewCallRecorder(),
+		RedactPatterns:  []*regexp.Regexp{regexp.MustCompile(`sk-live-\w+`)},
+	}
+	collab.Recorder.record(CallRecord{PhaseName: "Design", AgentName: "Alice", Object: "Alice", Prompt: "design it", Response: "done, key is sk-live-deadbeef", Tokens: 5})
+
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := collab.ExportSupportBundle(path); err != nil {
+		t.Fatalf("ExportSupportBundle failed: %v", err)
+	}
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("failed to reopen bundle: %v", err)
+	}
+	defer reader.Close()
+
+	var manifest supportManifest
+	if err := readJSONEntry(&reader.Reader, "manifest.json", &manifest); err != nil {
+		t.Fatalf("readJSONEntry(manifest.json) failed: %v", err)
+	}
+	if len(manifest.Agents) != 1 || manifest.Agents[0] != "Alice" {
+		t.Errorf("Expected manifest to list Alice, got %+v", manifest.Agents)
+	}
+	if manifest.PhaseMethods["Design"] != "Sequential" {
+		t.Errorf("Expected Design's method to be recorded as Sequential, got %q", manifest.PhaseMethods["Design"])
+	}
+	if manifest.CallCount != 1 {
+		t.Errorf("Expected 1 recorded call, got %d", manifest.CallCount)
+	}
+
+	var shared map[string]string
+	if err := readJSONEntry(&reader.Reader, "shared_knowledge.json", &shared); err != nil {
+		t.Fatalf("readJSONEntry(shared_knowledge.json) failed: %v", err)
+	}
+	if shared["Alice"] != "api-key=[REDACTED] is my secret" {
+		t.Errorf("Expected SharedKnowledge to be redacted, got %q", shared["Alice"])
+	}
+
+	file := findEntry(&reader.Reader, "calls.jsonl")
+	if file == nil {
+		t.Fatal("Expected calls.jsonl in the bundle")
+	}
+	rc, err := file.Open()
+	if err != nil {
+		t.Fatalf("failed to open calls.jsonl: %v", err)
+	}
+	defer rc.Close()
+	var call CallRecord
+	if err := json.NewDecoder(rc).Decode(&call); err != nil {
+		t.Fatalf("failed to decode calls.jsonl: %v", err)
+	}
+	if call.Response != "done, key is [REDACTED]" {
+		t.Errorf("Expected the recorded response to be redacted, got %q", call.Response)
+	}
+}
+
+func TestInspectSupportBundle(t *testing.T) {
+	collab := &Collaboration{
+		Agents:          []Agent{{Name: "Alice"}},
+		Phases:          []Phase{{Name: "Design"}},
+		SharedKnowledge: map[string]string{},
+		Recorder:        NewCallRecorder(),
+	}
+	collab.Recorder.record(CallRecord{PhaseName: "Design", AgentName: "Alice", Object: "Alice", Prompt: "hi", Response: "hello", Tokens: 2})
+
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := collab.ExportSupportBundle(path); err != nil {
+		t.Fatalf("ExportSupportBundle failed: %v", err)
+	}
+
+	summary, err := InspectSupportBundle(path)
+	if err != nil {
+		t.Fatalf("InspectSupportBundle failed: %v", err)
+	}
+	if !contains(summary, "Alice") || !contains(summary, "Design") {
+		t.Errorf("Expected the summary to mention Alice and Design, got %q", summary)
+	}
+}
+
+func contains(haystack, needle string) bool {
+	return len(haystack) >= len(needle) && (func() bool {
+		for i := 0; i+len(needle) <= len(haystack); i++ {
+			if haystack[i:i+len(needle)] == needle {
+				return true
+			}
+		}
+		return false
+	})()
+}
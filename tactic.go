@@ -0,0 +1,292 @@
+package breeze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// TacticStep records one node of an executed Tactic tree: which tactic ran,
+// what it produced, and any children it spawned. Collaboration.RunTactic
+// returns the root of this tree so callers can persist a reproducible
+// "proof script" showing which branches ran and which retries fired.
+type TacticStep struct {
+	Name           string       `json:"name"`
+	Input          string       `json:"input"`
+	Output         string       `json:"output"`
+	VerifierStatus string       `json:"verifier_status,omitempty"`
+	Children       []TacticStep `json:"children,omitempty"`
+}
+
+// Tactic is a composable unit of a collaboration's control flow, inspired by
+// proof-assistant tactic languages: a leaf asks (or reduces over, or polls)
+// some agents, and a combinator sequences, parallelizes, repeats, or
+// branches on other tactics.
+type Tactic interface {
+	run(c *Collaboration, input string) (string, TacticStep)
+}
+
+// RunTactic executes a Tactic against the collaboration's agents, threading
+// input through the tree and recording a TacticStep trace as it goes.
+func (c *Collaboration) RunTactic(t Tactic, input string) (string, TacticStep, error) {
+	output, trace := t.run(c, input)
+	return output, trace, nil
+}
+
+// SaveTacticTrace writes an executed TacticStep tree to filename as JSON, so
+// a run can be replayed or audited later (which branches ran, which repairs
+// fired, which verifier checks passed).
+func SaveTacticTrace(trace TacticStep, filename string) error {
+	data, err := json.MarshalIndent(trace, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal tactic trace: %w", err)
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// ===== Leaves =====
+
+type askTactic struct {
+	agent    Agent
+	template string
+}
+
+// Ask builds a leaf Tactic that sends template (with the running input
+// appended) to a single agent.
+func Ask(agent Agent, template string) Tactic {
+	return askTactic{agent: agent, template: template}
+}
+
+func (a askTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	prompt := fmt.Sprintf("%s\n\nINPUT:\n%s", a.template, input)
+	output := c.runAgentTurn(a.agent, prompt)
+	return output, TacticStep{Name: fmt.Sprintf("Ask(%s)", a.agent.Name), Input: input, Output: output}
+}
+
+type reduceTactic struct {
+	agents   []Agent
+	template string
+}
+
+// Reduce builds a leaf Tactic that asks every agent in parallel and
+// concatenates their contributions into a single combined output.
+func Reduce(agents []Agent, template string) Tactic {
+	return reduceTactic{agents: agents, template: template}
+}
+
+func (r reduceTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, len(r.agents))
+	sections := make([]string, len(r.agents))
+
+	var wg sync.WaitGroup
+	for i, agent := range r.agents {
+		wg.Add(1)
+		go func(i int, agent Agent) {
+			defer wg.Done()
+			prompt := fmt.Sprintf("%s\n\nINPUT:\n%s", r.template, input)
+			output := c.runAgentTurn(agent, prompt)
+			children[i] = TacticStep{Name: fmt.Sprintf("Ask(%s)", agent.Name), Input: input, Output: output}
+			sections[i] = fmt.Sprintf("## %s\n%s\n\n", agent.Name, output)
+		}(i, agent)
+	}
+	wg.Wait()
+
+	var combined string
+	for _, section := range sections {
+		combined += section
+	}
+	return combined, TacticStep{Name: "Reduce", Input: input, Output: combined, Children: children}
+}
+
+type voteTactic struct {
+	agents []Agent
+	k      int
+}
+
+// Vote builds a leaf Tactic that asks every agent the same question (the
+// input doubles as the prompt) and returns the k most common answers, voting
+// by exact text match.
+func Vote(agents []Agent, k int) Tactic {
+	return voteTactic{agents: agents, k: k}
+}
+
+func (v voteTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, len(v.agents))
+	counts := make(map[string]int)
+	order := make([]string, 0, len(v.agents))
+	for i, agent := range v.agents {
+		output := c.runAgentTurn(agent, input)
+		children[i] = TacticStep{Name: fmt.Sprintf("Ask(%s)", agent.Name), Input: input, Output: output}
+		if counts[output] == 0 {
+			order = append(order, output)
+		}
+		counts[output]++
+	}
+
+	k := v.k
+	if k <= 0 || k > len(order) {
+		k = len(order)
+	}
+
+	var winners string
+	for i := 0; i < k; i++ {
+		best := ""
+		bestCount := -1
+		for _, candidate := range order {
+			if counts[candidate] > bestCount {
+				best = candidate
+				bestCount = counts[candidate]
+			}
+		}
+		winners += best + "\n\n"
+		counts[best] = -1 // don't pick it again
+	}
+
+	return winners, TacticStep{Name: "Vote", Input: input, Output: winners, Children: children}
+}
+
+// ===== Combinators =====
+
+type seqTactic struct {
+	steps []Tactic
+}
+
+// Seq runs tactics one after another, threading each one's output as the
+// next one's input.
+func Seq(steps ...Tactic) Tactic {
+	return seqTactic{steps: steps}
+}
+
+func (s seqTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, 0, len(s.steps))
+	output := input
+	for _, step := range s.steps {
+		var child TacticStep
+		output, child = step.run(c, output)
+		children = append(children, child)
+	}
+	return output, TacticStep{Name: "Seq", Input: input, Output: output, Children: children}
+}
+
+type parallelTactic struct {
+	branches []Tactic
+}
+
+// Fork runs every branch against the same input and concatenates their
+// outputs. Named Fork (not Parallel) to stay distinct from the
+// CollaborationMethod Parallel, which runs a phase's *agents* concurrently
+// rather than a Tactic tree's branches.
+func Fork(branches ...Tactic) Tactic {
+	return parallelTactic{branches: branches}
+}
+
+func (p parallelTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, len(p.branches))
+	outputs := make([]string, len(p.branches))
+
+	var wg sync.WaitGroup
+	for i, branch := range p.branches {
+		wg.Add(1)
+		go func(i int, branch Tactic) {
+			defer wg.Done()
+			output, child := branch.run(c, input)
+			children[i] = child
+			outputs[i] = output
+		}(i, branch)
+	}
+	wg.Wait()
+
+	var combined string
+	for _, output := range outputs {
+		combined += output + "\n\n"
+	}
+	return combined, TacticStep{Name: "Parallel", Input: input, Output: combined, Children: children}
+}
+
+type repeatTactic struct {
+	n int
+	t Tactic
+}
+
+// Repeat runs t n times, feeding each run's output as the next run's input.
+func Repeat(n int, t Tactic) Tactic {
+	return repeatTactic{n: n, t: t}
+}
+
+func (r repeatTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, 0, r.n)
+	output := input
+	for i := 0; i < r.n; i++ {
+		var child TacticStep
+		output, child = r.t.run(c, output)
+		children = append(children, child)
+	}
+	return output, TacticStep{Name: fmt.Sprintf("Repeat(%d)", r.n), Input: input, Output: output, Children: children}
+}
+
+// maxUntilIterations bounds Until so a predicate that never returns true
+// can't loop forever.
+const maxUntilIterations = 10
+
+type untilTactic struct {
+	pred func(output string) bool
+	t    Tactic
+}
+
+// Until repeats t (feeding its own output back as input) until pred returns
+// true, or maxUntilIterations is reached.
+func Until(pred func(output string) bool, t Tactic) Tactic {
+	return untilTactic{pred: pred, t: t}
+}
+
+func (u untilTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	children := make([]TacticStep, 0)
+	output := input
+	for i := 0; i < maxUntilIterations; i++ {
+		var child TacticStep
+		output, child = u.t.run(c, output)
+		children = append(children, child)
+		if u.pred(output) {
+			break
+		}
+	}
+	return output, TacticStep{Name: "Until", Input: input, Output: output, Children: children}
+}
+
+type ifVerifiedTactic struct {
+	problem  string
+	verifier Verifier
+	then     Tactic
+	els      Tactic
+}
+
+// IfVerified runs `then`, checks its output against verifier using problem
+// as the original statement, and falls back to running `els` (on the same
+// input) if the check is Refuted or Inconclusive.
+func IfVerified(problem string, verifier Verifier, then, els Tactic) Tactic {
+	return ifVerifiedTactic{problem: problem, verifier: verifier, then: then, els: els}
+}
+
+func (iv ifVerifiedTactic) run(c *Collaboration, input string) (string, TacticStep) {
+	output, child := iv.then.run(c, input)
+	result := iv.verifier.Verify(iv.problem, output)
+	if result.Status == Verified {
+		return output, TacticStep{
+			Name:           "IfVerified(then)",
+			Input:          input,
+			Output:         output,
+			VerifierStatus: result.Status.String(),
+			Children:       []TacticStep{child},
+		}
+	}
+
+	elsOutput, elsChild := iv.els.run(c, input)
+	return elsOutput, TacticStep{
+		Name:           "IfVerified(else)",
+		Input:          input,
+		Output:         elsOutput,
+		VerifierStatus: result.Status.String(),
+		Children:       []TacticStep{child, elsChild},
+	}
+}
@@ -0,0 +1,91 @@
+package breeze
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// barrierBackend is a Backend stub that blocks each Generate call until n
+// calls are in flight simultaneously, then releases them all at once. A
+// tactic combinator that actually runs its agents/branches concurrently
+// lets every call reach the barrier and return "ok"; one that runs them
+// sequentially deadlocks the first call (since later calls, which would
+// complete the barrier, never start) until the timeout fires and an error
+// is returned instead.
+type barrierBackend struct {
+	n       int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	started int
+	release chan struct{}
+}
+
+func newBarrierBackend(n int) *barrierBackend {
+	return &barrierBackend{n: n, timeout: 2 * time.Second, release: make(chan struct{})}
+}
+
+func (b *barrierBackend) Generate(_ context.Context, _ []Message, _ RequestOptions) (string, error) {
+	b.mu.Lock()
+	b.started++
+	allStarted := b.started == b.n
+	b.mu.Unlock()
+
+	if allStarted {
+		close(b.release)
+	}
+
+	select {
+	case <-b.release:
+		return "ok", nil
+	case <-time.After(b.timeout):
+		return "", fmt.Errorf("timed out waiting for %d concurrent calls to start (only %d did)", b.n, b.started)
+	}
+}
+
+func (b *barrierBackend) GenerateStream(_ context.Context, _ []Message, _ RequestOptions) (<-chan Token, func() error) {
+	ch := make(chan Token)
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+func TestReduce_RunsAgentsConcurrently(t *testing.T) {
+	backend := newBarrierBackend(3)
+	agents := []Agent{
+		{Name: "A", Backend: backend},
+		{Name: "B", Backend: backend},
+		{Name: "C", Backend: backend},
+	}
+	collab := &Collaboration{Agents: agents, SharedKnowledge: make(map[string]string)}
+
+	output, trace := Reduce(agents, "contribute").run(collab, "the problem")
+
+	if len(trace.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(trace.Children))
+	}
+	for _, agent := range agents {
+		if !strings.Contains(output, agent.Name) {
+			t.Errorf("Expected output to include a section for %s, got %q", agent.Name, output)
+		}
+	}
+}
+
+func TestFork_RunsBranchesConcurrently(t *testing.T) {
+	backend := newBarrierBackend(2)
+	agentA := Agent{Name: "A", Backend: backend}
+	agentB := Agent{Name: "B", Backend: backend}
+	collab := &Collaboration{Agents: []Agent{agentA, agentB}, SharedKnowledge: make(map[string]string)}
+
+	output, trace := Fork(Ask(agentA, "go"), Ask(agentB, "go")).run(collab, "the problem")
+
+	if len(trace.Children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(trace.Children))
+	}
+	if !strings.Contains(output, "ok") {
+		t.Errorf("Expected both branches to complete past the barrier, got %q", output)
+	}
+}
@@ -0,0 +1,257 @@
+package breeze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolvePhaseDependencies returns, for every phase, the names it depends
+// on: Phase.DependsOn verbatim if set, or the previous phase in Phases
+// order otherwise (the first phase depends on nothing), implementing the
+// "empty DependsOn means linear" default described on Phase.DependsOn.
+func resolvePhaseDependencies(phases []Phase) map[string][]string {
+	deps := make(map[string][]string, len(phases))
+	for i, phase := range phases {
+		switch {
+		case len(phase.DependsOn) > 0:
+			deps[phase.Name] = phase.DependsOn
+		case i > 0:
+			deps[phase.Name] = []string{phases[i-1].Name}
+		default:
+			deps[phase.Name] = nil
+		}
+	}
+	return deps
+}
+
+// validatePhaseGraph checks phases' resolved DependsOn for references to
+// unknown phases and cyclic dependencies, returning the first problem
+// found. Called once at NewTeamCollaboration time so Run can fail fast
+// instead of deadlocking on a broken DAG.
+func validatePhaseGraph(phases []Phase) error {
+	names := make(map[string]bool, len(phases))
+	for _, phase := range phases {
+		names[phase.Name] = true
+	}
+	deps := resolvePhaseDependencies(phases)
+	for _, phase := range phases {
+		for _, dep := range deps[phase.Name] {
+			if !names[dep] {
+				return fmt.Errorf("team collaboration: phase %q depends_on unknown phase %q", phase.Name, dep)
+			}
+		}
+	}
+	_, err := phaseLayers(phases)
+	return err
+}
+
+// phaseLayers groups phases into dependency layers via Kahn's algorithm:
+// every phase in a layer has all its resolved DependsOn satisfied by an
+// earlier layer, so Run can execute every phase within a layer
+// concurrently. Returns an error naming a cycle if phases don't form a DAG.
+func phaseLayers(phases []Phase) ([][]Phase, error) {
+	deps := resolvePhaseDependencies(phases)
+
+	byName := make(map[string]Phase, len(phases))
+	inDegree := make(map[string]int, len(phases))
+	dependents := make(map[string][]string)
+	for _, phase := range phases {
+		byName[phase.Name] = phase
+		if _, ok := inDegree[phase.Name]; !ok {
+			inDegree[phase.Name] = 0
+		}
+	}
+	for _, phase := range phases {
+		for _, dep := range deps[phase.Name] {
+			dependents[dep] = append(dependents[dep], phase.Name)
+			inDegree[phase.Name]++
+		}
+	}
+
+	var ready []string
+	for _, phase := range phases {
+		if inDegree[phase.Name] == 0 {
+			ready = append(ready, phase.Name)
+		}
+	}
+
+	var layers [][]Phase
+	scheduled := 0
+	for len(ready) > 0 {
+		layer := make([]Phase, 0, len(ready))
+		var next []string
+		for _, name := range ready {
+			layer = append(layer, byName[name])
+			scheduled++
+			for _, dependent := range dependents[name] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		layers = append(layers, layer)
+		ready = next
+	}
+
+	if scheduled != len(phases) {
+		return nil, fmt.Errorf("team collaboration: phases have a cyclic dependency")
+	}
+	return layers, nil
+}
+
+// setSharedKnowledge records agentName's latest output and wakes any
+// goroutine blocked in waitForAgentDeps on it.
+func (tc *TeamCollaboration) setSharedKnowledge(agentName, response string) {
+	tc.knowledgeMu.Lock()
+	tc.SharedKnowledge[agentName] = response
+	tc.knowledgeCond.Broadcast()
+	tc.knowledgeMu.Unlock()
+}
+
+// knowledgeSnapshot returns a point-in-time copy of SharedKnowledge, safe
+// to read from a goroutine other than the one updating it via
+// setSharedKnowledge.
+func (tc *TeamCollaboration) knowledgeSnapshot() map[string]string {
+	tc.knowledgeMu.Lock()
+	defer tc.knowledgeMu.Unlock()
+	snapshot := make(map[string]string, len(tc.SharedKnowledge))
+	for name, response := range tc.SharedKnowledge {
+		snapshot[name] = response
+	}
+	return snapshot
+}
+
+// waitForAgentDeps blocks until every agent named in
+// phase.AgentDependsOn[agent.Name] has a SharedKnowledge entry, letting
+// this one agent start as soon as those specific agents finish rather than
+// waiting for phase.DependsOn's phases to fully complete. A no-op when
+// AgentDependsOn has no entry for agent.
+func (tc *TeamCollaboration) waitForAgentDeps(agent Agent, phase Phase) {
+	deps := phase.AgentDependsOn[agent.Name]
+	if len(deps) == 0 {
+		return
+	}
+	tc.knowledgeMu.Lock()
+	defer tc.knowledgeMu.Unlock()
+	for !agentDepsReadyLocked(tc.SharedKnowledge, deps) {
+		tc.knowledgeCond.Wait()
+	}
+}
+
+// agentDepsReadyLocked reports whether every name in deps has an entry in
+// knowledge. Caller must hold tc.knowledgeMu.
+func agentDepsReadyLocked(knowledge map[string]string, deps []string) bool {
+	for _, dep := range deps {
+		if _, ok := knowledge[dep]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validateAgentDependencies checks every phase's AgentDependsOn for agent
+// names that don't exist in any team and for cycles among agents within the
+// same phase, returning the first problem found. Called once at
+// NewTeamCollaboration time for the same reason as validatePhaseGraph: a
+// dependency naming an agent that will never run (typo, or a cycle where
+// each side waits on the other) would otherwise block that agent's
+// waitForAgentDeps forever instead of failing fast.
+func validateAgentDependencies(teams []Team, phases []Phase) error {
+	knownAgents := make(map[string]bool)
+	for _, team := range teams {
+		for _, agent := range team.Agents {
+			knownAgents[agent.Name] = true
+		}
+	}
+
+	for _, phase := range phases {
+		if len(phase.AgentDependsOn) == 0 {
+			continue
+		}
+
+		inDegree := make(map[string]int)
+		dependents := make(map[string][]string)
+		nodes := make(map[string]bool)
+
+		for agentName, deps := range phase.AgentDependsOn {
+			if !knownAgents[agentName] {
+				return fmt.Errorf("team collaboration: phase %q AgentDependsOn names unknown agent %q", phase.Name, agentName)
+			}
+			nodes[agentName] = true
+			if _, ok := inDegree[agentName]; !ok {
+				inDegree[agentName] = 0
+			}
+			for _, dep := range deps {
+				if !knownAgents[dep] {
+					return fmt.Errorf("team collaboration: phase %q AgentDependsOn(%s) names unknown agent %q", phase.Name, agentName, dep)
+				}
+				nodes[dep] = true
+				dependents[dep] = append(dependents[dep], agentName)
+				inDegree[agentName]++
+			}
+		}
+
+		var ready []string
+		for name := range nodes {
+			if inDegree[name] == 0 {
+				ready = append(ready, name)
+			}
+		}
+		scheduled := 0
+		for len(ready) > 0 {
+			name := ready[len(ready)-1]
+			ready = ready[:len(ready)-1]
+			scheduled++
+			for _, dependent := range dependents[name] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					ready = append(ready, dependent)
+				}
+			}
+		}
+		if scheduled != len(nodes) {
+			return fmt.Errorf("team collaboration: phase %q has a cyclic AgentDependsOn", phase.Name)
+		}
+	}
+	return nil
+}
+
+// Graphviz renders tc.Phases' dependency DAG as a Graphviz "dot" document:
+// a solid edge per phase-level DependsOn, a dashed edge per AgentDependsOn
+// entry, and each phase node labeled with its Produces keys if set.
+func (tc *TeamCollaboration) Graphviz() string {
+	deps := resolvePhaseDependencies(tc.Phases)
+
+	var b strings.Builder
+	b.WriteString("digraph TeamCollaboration {\n")
+
+	for _, phase := range tc.Phases {
+		label := phase.Name
+		if len(phase.Produces) > 0 {
+			label += "\\nproduces: " + strings.Join(phase.Produces, ", ")
+		}
+		fmt.Fprintf(&b, "  %q [label=%q];\n", phase.Name, label)
+	}
+	for _, phase := range tc.Phases {
+		for _, dep := range deps[phase.Name] {
+			fmt.Fprintf(&b, "  %q -> %q;\n", dep, phase.Name)
+		}
+	}
+	for _, phase := range tc.Phases {
+		agentNames := make([]string, 0, len(phase.AgentDependsOn))
+		for agentName := range phase.AgentDependsOn {
+			agentNames = append(agentNames, agentName)
+		}
+		sort.Strings(agentNames)
+		for _, agentName := range agentNames {
+			for _, dep := range phase.AgentDependsOn[agentName] {
+				fmt.Fprintf(&b, "  %q -> %q [style=dashed, label=\"agent\"];\n", dep, agentName)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,170 @@
+package breeze
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAgentProvider is a Provider that returns agentName's canned response
+// instead of calling a real LLM, so TeamCollaboration.Run can be exercised
+// in these tests without a network dependency. Responses are keyed by the
+// "AGENT: <name>" line buildTeamAgentPrompt always writes first.
+type fakeAgentProvider struct{}
+
+func (fakeAgentProvider) Generate(ctx context.Context, opts RequestOptions, prompt string) (string, error) {
+	return "ok", nil
+}
+
+func (fakeAgentProvider) GenerateStream(ctx context.Context, opts RequestOptions, prompt string) (<-chan Token, func() error) {
+	ch := make(chan Token)
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+func (fakeAgentProvider) Embed(ctx context.Context, opts RequestOptions, text string) ([]float32, error) {
+	return nil, nil
+}
+
+func (fakeAgentProvider) ListModels(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func TestPhaseLayers_DefaultsToLinearOrder(t *testing.T) {
+	phases := []Phase{{Name: "A"}, {Name: "B"}, {Name: "C"}}
+	layers, err := phaseLayers(phases)
+	if err != nil {
+		t.Fatalf("phaseLayers failed: %v", err)
+	}
+	if len(layers) != 3 {
+		t.Fatalf("Expected 3 layers for a linear chain, got %d: %+v", len(layers), layers)
+	}
+	for i, layer := range layers {
+		if len(layer) != 1 || layer[0].Name != phases[i].Name {
+			t.Errorf("Expected layer %d to be [%s], got %+v", i, phases[i].Name, layer)
+		}
+	}
+}
+
+func TestPhaseLayers_DivergeAndReconverge(t *testing.T) {
+	phases := []Phase{
+		{Name: "Requirements"},
+		{Name: "SW Implementation", DependsOn: []string{"Requirements"}},
+		{Name: "Testing", DependsOn: []string{"Requirements"}},
+		{Name: "Final Polish", DependsOn: []string{"SW Implementation", "Testing"}},
+	}
+	layers, err := phaseLayers(phases)
+	if err != nil {
+		t.Fatalf("phaseLayers failed: %v", err)
+	}
+	if len(layers) != 3 {
+		t.Fatalf("Expected 3 layers, got %d: %+v", len(layers), layers)
+	}
+	if len(layers[1]) != 2 {
+		t.Fatalf("Expected SW Implementation and Testing to share a layer, got %+v", layers[1])
+	}
+}
+
+func TestValidatePhaseGraph_DetectsCycle(t *testing.T) {
+	phases := []Phase{
+		{Name: "A", DependsOn: []string{"B"}},
+		{Name: "B", DependsOn: []string{"A"}},
+	}
+	if err := validatePhaseGraph(phases); err == nil {
+		t.Error("Expected validatePhaseGraph to reject a cyclic dependency")
+	}
+}
+
+func TestValidatePhaseGraph_UnknownDependency(t *testing.T) {
+	phases := []Phase{{Name: "A", DependsOn: []string{"NoSuchPhase"}}}
+	if err := validatePhaseGraph(phases); err == nil {
+		t.Error("Expected validatePhaseGraph to reject a DependsOn naming an unknown phase")
+	}
+}
+
+func TestNewTeamCollaboration_RunRejectsCycle(t *testing.T) {
+	phases := []Phase{
+		{Name: "A", DependsOn: []string{"B"}},
+		{Name: "B", DependsOn: []string{"A"}},
+	}
+	tc := NewTeamCollaboration(nil, phases)
+	if _, err := tc.Run("project"); err == nil {
+		t.Error("Expected Run to reject a cyclic phase graph instead of deadlocking")
+	}
+}
+
+func TestTeamCollaboration_Run_CrossTeamAgentDependsOn(t *testing.T) {
+	prev := defaultProvider
+	SetDefaultProvider(fakeAgentProvider{})
+	defer SetDefaultProvider(prev)
+
+	teams := []Team{
+		{Name: "SW", Agents: []Agent{{Name: "Backend Dev"}}},
+		{Name: "Testing", Agents: []Agent{{Name: "Backend Tester"}}},
+	}
+	phases := []Phase{
+		{
+			Name:           "Implementation",
+			IsParallel:     true,
+			AgentDependsOn: map[string][]string{"Backend Dev": {"Backend Tester"}},
+		},
+	}
+	tc := NewTeamCollaboration(teams, phases)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := tc.Run("build the widget")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Run deadlocked: a same-phase AgentDependsOn across teams never resolved")
+	}
+}
+
+func TestNewTeamCollaboration_RejectsUnknownAgentDependsOn(t *testing.T) {
+	teams := []Team{{Name: "SW", Agents: []Agent{{Name: "Backend Dev"}}}}
+	phases := []Phase{{Name: "Implementation", AgentDependsOn: map[string][]string{"Backend Dev": {"Nobody"}}}}
+	tc := NewTeamCollaboration(teams, phases)
+	if _, err := tc.Run("project"); err == nil {
+		t.Error("Expected Run to reject an AgentDependsOn naming an unknown agent")
+	}
+}
+
+func TestNewTeamCollaboration_RejectsCyclicAgentDependsOn(t *testing.T) {
+	teams := []Team{{Name: "SW", Agents: []Agent{{Name: "A"}, {Name: "B"}}}}
+	phases := []Phase{{Name: "Implementation", AgentDependsOn: map[string][]string{
+		"A": {"B"},
+		"B": {"A"},
+	}}}
+	tc := NewTeamCollaboration(teams, phases)
+	if _, err := tc.Run("project"); err == nil {
+		t.Error("Expected Run to reject a cyclic AgentDependsOn instead of deadlocking")
+	}
+}
+
+func TestTeamCollaboration_Graphviz(t *testing.T) {
+	phases := []Phase{
+		{Name: "Requirements", Produces: []string{"plan"}},
+		{Name: "Implementation", DependsOn: []string{"Requirements"}, AgentDependsOn: map[string][]string{"Backend Dev": {"Backend Tester"}}},
+	}
+	tc := NewTeamCollaboration(nil, phases)
+	dot := tc.Graphviz()
+
+	for _, want := range []string{
+		"digraph TeamCollaboration",
+		`"Requirements" -> "Implementation"`,
+		`"Backend Tester" -> "Backend Dev"`,
+		"produces: plan",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Expected Graphviz output to contain %q, got:\n%s", want, dot)
+		}
+	}
+}
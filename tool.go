@@ -0,0 +1,303 @@
+package breeze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Tool is a callable function an Agent can invoke mid-turn. Schema is a JSON
+// schema describing Fn's expected arguments, shown to the model so it knows
+// how to call the tool.
+type Tool struct {
+	Name        string
+	Description string
+	Schema      json.RawMessage
+	Fn          func(ctx context.Context, args json.RawMessage) (string, error)
+}
+
+// defaultMaxToolIterations bounds the tool-call loop when
+// Collaboration.MaxToolIterations is left at zero.
+const defaultMaxToolIterations = 5
+
+// toolCallPattern matches a model reply that wants to invoke a tool, using
+// the simple convention: a line of the form
+//
+//	TOOL_CALL: {"name": "read_file", "args": {"path": "README.md"}}
+var toolCallPattern = regexp.MustCompile(`(?m)^TOOL_CALL:\s*(\{.*\})\s*$`)
+
+type toolCall struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args"`
+}
+
+// ToolInvocation records one tool call an agent made during
+// runAgentTurnWithTools, for later inspection via DecodeToolInvocations.
+type ToolInvocation struct {
+	Tool   string          `json:"tool"`
+	Args   json.RawMessage `json:"args"`
+	Result string          `json:"result"`
+	Err    string          `json:"err,omitempty"`
+}
+
+// toolInvocationsKeyPrefix namespaces Collaboration.SharedKnowledge keys
+// recording an agent's tool calls (see recordToolInvocation), distinct from
+// the plain agentName keys runCollaborationLoop uses for response text.
+const toolInvocationsKeyPrefix = "toolcalls/"
+
+// recordToolInvocation appends call to
+// collab.SharedKnowledge["toolcalls/"+agentName], JSON-encoding the growing
+// list the same way mergeCoderArtifacts stashes structured data in
+// SharedKnowledge.
+func recordToolInvocation(collab *Collaboration, agentName string, call ToolInvocation) {
+	key := toolInvocationsKeyPrefix + agentName
+	invocations, err := DecodeToolInvocations(collab.SharedKnowledge, agentName)
+	if err != nil {
+		invocations = nil
+	}
+	invocations = append(invocations, call)
+
+	encoded, err := json.Marshal(invocations)
+	if err != nil {
+		fmt.Printf("⚠️  failed to encode tool invocations for %s: %v\n", agentName, err)
+		return
+	}
+	collab.SharedKnowledge[key] = string(encoded)
+}
+
+// DecodeToolInvocations reads back the []ToolInvocation recordToolInvocation
+// stashed into sharedKnowledge["toolcalls/"+agentName]. An agent with no
+// recorded tool calls returns an empty slice, not an error.
+func DecodeToolInvocations(sharedKnowledge map[string]string, agentName string) ([]ToolInvocation, error) {
+	encoded, ok := sharedKnowledge[toolInvocationsKeyPrefix+agentName]
+	if !ok {
+		return nil, nil
+	}
+	var invocations []ToolInvocation
+	if err := json.Unmarshal([]byte(encoded), &invocations); err != nil {
+		return nil, fmt.Errorf("decode tool invocations for %s: %w", agentName, err)
+	}
+	return invocations, nil
+}
+
+// runAgentTurnWithTools performs the standard tool-calling loop for an
+// agent's turn: send the prompt (with tool schemas appended), detect a tool
+// call in the reply, execute it, append the result, and re-prompt until the
+// model returns a final answer or MaxToolIterations is hit. imagePaths are
+// forwarded to every underlying runAgentTurn call (see its doc comment).
+// Every call is also recorded into SharedKnowledge (see
+// recordToolInvocation) so later phases/callers can see which tools an
+// agent used.
+func (c *Collaboration) runAgentTurnWithTools(agent Agent, prompt string, imagePaths ...string) string {
+	if len(agent.Tools) == 0 {
+		return c.runAgentTurn(agent, prompt, imagePaths...)
+	}
+
+	maxIterations := c.MaxToolIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultMaxToolIterations
+	}
+
+	turnPrompt := prompt + "\n\n" + renderToolDescriptions(agent.Tools)
+
+	for i := 0; i < maxIterations; i++ {
+		response := c.runAgentTurn(agent, turnPrompt, imagePaths...)
+
+		match := toolCallPattern.FindStringSubmatch(response)
+		if match == nil {
+			return response
+		}
+
+		var call toolCall
+		if err := json.Unmarshal([]byte(match[1]), &call); err != nil {
+			return response
+		}
+
+		tool := findTool(agent.Tools, call.Name)
+		if tool == nil {
+			turnPrompt = fmt.Sprintf("%s\n\nTOOL RESULT for unknown tool %q: no such tool is available.", turnPrompt, call.Name)
+			continue
+		}
+
+		result, err := tool.Fn(c.toolCtx(), call.Args)
+		invocation := ToolInvocation{Tool: call.Name, Args: call.Args, Result: result}
+		if err != nil {
+			result = fmt.Sprintf("error: %v", err)
+			invocation.Err = err.Error()
+		}
+		recordToolInvocation(c, agent.Name, invocation)
+
+		turnPrompt = fmt.Sprintf("%s\n\nYOUR PREVIOUS REPLY:\n%s\n\nTOOL RESULT for %s:\n%s\n\nContinue, or provide your final answer.",
+			turnPrompt, response, call.Name, result)
+	}
+
+	return c.runAgentTurn(agent, turnPrompt, imagePaths...)
+}
+
+// toolCtx returns c.Ctx, defaulting to context.Background(), mirroring
+// reqCtx for RequestOptions. Governs cancellation of in-flight tool calls
+// made during runAgentTurnWithTools.
+func (c *Collaboration) toolCtx() context.Context {
+	if c.Ctx != nil {
+		return c.Ctx
+	}
+	return context.Background()
+}
+
+func findTool(tools []Tool, name string) *Tool {
+	for i := range tools {
+		if tools[i].Name == name {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+func renderToolDescriptions(tools []Tool) string {
+	var b strings.Builder
+	b.WriteString("AVAILABLE TOOLS:\n")
+	for _, t := range tools {
+		b.WriteString(fmt.Sprintf("- %s: %s\n  schema: %s\n", t.Name, t.Description, string(t.Schema)))
+	}
+	b.WriteString("\nTo call a tool, reply with a single line: TOOL_CALL: {\"name\": \"<tool>\", \"args\": {...}}\n")
+	b.WriteString("Otherwise, reply with your final answer.\n")
+	return b.String()
+}
+
+// ===== Built-in tool library =====
+
+// ReadFileTool returns a Tool that reads a file's contents, refusing to
+// escape workdir.
+func ReadFileTool(workdir string) Tool {
+	return Tool{
+		Name:        "read_file",
+		Description: "Read a text file's contents, relative to the tool's working directory.",
+		Schema:      json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}},"required":["path"]}`),
+		Fn: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			full, err := scopedPath(workdir, a.Path)
+			if err != nil {
+				return "", err
+			}
+			data, err := os.ReadFile(full)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+	}
+}
+
+// WriteFileTool returns a Tool that writes a file's contents, refusing to
+// escape workdir.
+func WriteFileTool(workdir string) Tool {
+	return Tool{
+		Name:        "write_file",
+		Description: "Write a text file's contents, relative to the tool's working directory.",
+		Schema:      json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"},"content":{"type":"string"}},"required":["path","content"]}`),
+		Fn: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Path    string `json:"path"`
+				Content string `json:"content"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			full, err := scopedPath(workdir, a.Path)
+			if err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(full, []byte(a.Content), 0644); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("wrote %d bytes to %s", len(a.Content), a.Path), nil
+		},
+	}
+}
+
+// scopedPath resolves path relative to workdir and rejects any result that
+// escapes it (e.g. via "..").
+func scopedPath(workdir, path string) (string, error) {
+	full := filepath.Join(workdir, path)
+	rel, err := filepath.Rel(workdir, full)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("path %q escapes workdir", path)
+	}
+	return full, nil
+}
+
+// HTTPGetTool returns a Tool that fetches a URL over HTTP GET and returns
+// its body as text.
+func HTTPGetTool() Tool {
+	return Tool{
+		Name:        "http_get",
+		Description: "Fetch a URL via HTTP GET and return the response body.",
+		Schema:      json.RawMessage(`{"type":"object","properties":{"url":{"type":"string"}},"required":["url"]}`),
+		Fn: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+			if err != nil {
+				return "", err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return "", err
+			}
+			return string(body), nil
+		},
+	}
+}
+
+// ShellTool returns a Tool that runs a shell command, but only if its first
+// word is present in allowlist. This is a coarse guard, not a sandbox.
+func ShellTool(allowlist []string) Tool {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, cmd := range allowlist {
+		allowed[cmd] = true
+	}
+
+	return Tool{
+		Name:        "shell",
+		Description: fmt.Sprintf("Run a shell command whose first word is one of: %s", strings.Join(allowlist, ", ")),
+		Schema:      json.RawMessage(`{"type":"object","properties":{"command":{"type":"string"}},"required":["command"]}`),
+		Fn: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Command string `json:"command"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			fields := strings.Fields(a.Command)
+			if len(fields) == 0 || !allowed[fields[0]] {
+				return "", fmt.Errorf("command %q is not on the allowlist", a.Command)
+			}
+			out, err := exec.CommandContext(ctx, "sh", "-c", a.Command).CombinedOutput()
+			if err != nil {
+				return string(out), fmt.Errorf("command failed: %w", err)
+			}
+			return string(out), nil
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package breeze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// toolCallingBackend is a Backend stub that replies with a TOOL_CALL on its
+// first call and finalReply on every subsequent call, so a test can drive
+// runAgentTurnWithTools through exactly one tool invocation before it
+// terminates.
+type toolCallingBackend struct {
+	toolName   string
+	toolArgs   string
+	finalReply string
+	calls      int
+}
+
+func (b *toolCallingBackend) Generate(_ context.Context, _ []Message, _ RequestOptions) (string, error) {
+	b.calls++
+	if b.calls == 1 {
+		return fmt.Sprintf(`TOOL_CALL: {"name": %q, "args": %s}`, b.toolName, b.toolArgs), nil
+	}
+	return b.finalReply, nil
+}
+
+func (b *toolCallingBackend) GenerateStream(ctx context.Context, messages []Message, opts RequestOptions) (<-chan Token, func() error) {
+	ch := make(chan Token, 1)
+	text, _ := b.Generate(ctx, messages, opts)
+	ch <- Token{Text: text}
+	close(ch)
+	return ch, func() error { return nil }
+}
+
+func TestRunAgentTurnWithTools_ThreadsResultBackAndRecordsInvocation(t *testing.T) {
+	backend := &toolCallingBackend{toolName: "add", toolArgs: `{"a":1,"b":2}`, finalReply: "the sum is 3"}
+	addTool := Tool{
+		Name:   "add",
+		Schema: json.RawMessage(`{"type":"object"}`),
+		Fn: func(_ context.Context, args json.RawMessage) (string, error) {
+			return "3", nil
+		},
+	}
+	agent := Agent{Name: "Alice", Backend: backend, Tools: []Tool{addTool}}
+	collab := &Collaboration{Agents: []Agent{agent}, SharedKnowledge: make(map[string]string)}
+
+	output := collab.runAgentTurnWithTools(agent, "what is 1+2?")
+
+	if output != "the sum is 3" {
+		t.Errorf("Expected the loop to terminate with the final reply, got %q", output)
+	}
+	if backend.calls != 2 {
+		t.Fatalf("Expected exactly 2 backend calls (tool call, then final answer), got %d", backend.calls)
+	}
+
+	invocations, err := DecodeToolInvocations(collab.SharedKnowledge, "Alice")
+	if err != nil {
+		t.Fatalf("DecodeToolInvocations: %v", err)
+	}
+	if len(invocations) != 1 {
+		t.Fatalf("Expected 1 recorded invocation, got %d", len(invocations))
+	}
+	if invocations[0].Tool != "add" || invocations[0].Result != "3" {
+		t.Errorf("Expected a recorded add invocation with result 3, got %+v", invocations[0])
+	}
+}
+
+func TestDecodeToolInvocations_AbsentKey(t *testing.T) {
+	invocations, err := DecodeToolInvocations(map[string]string{}, "Alice")
+	if err != nil {
+		t.Fatalf("expected no error for an absent key, got %v", err)
+	}
+	if invocations != nil {
+		t.Errorf("Expected a nil slice for an absent key, got %+v", invocations)
+	}
+}
+
+func TestRunAgentTurnWithTools_HonorsCollaborationCtx(t *testing.T) {
+	backend := &toolCallingBackend{toolName: "check", toolArgs: `{}`, finalReply: "done"}
+	var sawErr error
+	checkTool := Tool{
+		Name:   "check",
+		Schema: json.RawMessage(`{"type":"object"}`),
+		Fn: func(ctx context.Context, _ json.RawMessage) (string, error) {
+			sawErr = ctx.Err()
+			return "ok", nil
+		},
+	}
+	agent := Agent{Name: "Alice", Backend: backend, Tools: []Tool{checkTool}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	collab := &Collaboration{Agents: []Agent{agent}, SharedKnowledge: make(map[string]string), Ctx: ctx}
+
+	collab.runAgentTurnWithTools(agent, "check something")
+
+	if sawErr == nil {
+		t.Errorf("Expected the tool's Fn to observe the Collaboration's canceled context, got nil error")
+	}
+}
@@ -0,0 +1,208 @@
+// Package toolbox provides a starter set of breeze.ToolSpecs
+// (read_file, write_file, shell_exec, http_get, search_web) so
+// AI/Chat calls made with breeze.WithTools, and Agents in the
+// collaboration framework, can actually do work instead of only
+// exchanging text.
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/user/breeze"
+)
+
+// ReadFile returns a ToolSpec that reads a text file's contents, relative
+// to workdir, refusing to escape it.
+func ReadFile(workdir string) breeze.ToolSpec {
+	return breeze.ToolSpec{
+		Name:        "read_file",
+		Description: "Read a text file's contents, relative to the tool's working directory.",
+		Parameters:  json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}},"required":["path"]}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			full, err := scopedPath(workdir, a.Path)
+			if err != nil {
+				return "", err
+			}
+			data, err := os.ReadFile(full)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+	}
+}
+
+// WriteFile returns a ToolSpec that writes a text file's contents, relative
+// to workdir, refusing to escape it.
+func WriteFile(workdir string) breeze.ToolSpec {
+	return breeze.ToolSpec{
+		Name:        "write_file",
+		Description: "Write a text file's contents, relative to the tool's working directory.",
+		Parameters:  json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"},"content":{"type":"string"}},"required":["path","content"]}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Path    string `json:"path"`
+				Content string `json:"content"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			full, err := scopedPath(workdir, a.Path)
+			if err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(full, []byte(a.Content), 0644); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("wrote %d bytes to %s", len(a.Content), a.Path), nil
+		},
+	}
+}
+
+// scopedPath resolves path relative to workdir and rejects any result that
+// escapes it (e.g. via "..").
+func scopedPath(workdir, path string) (string, error) {
+	full := filepath.Join(workdir, path)
+	rel, err := filepath.Rel(workdir, full)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("path %q escapes workdir", path)
+	}
+	return full, nil
+}
+
+// ShellExec returns a ToolSpec that runs a shell command, but only if its
+// first word is present in allowlist. This is a coarse guard, not a
+// sandbox.
+func ShellExec(allowlist []string) breeze.ToolSpec {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, cmd := range allowlist {
+		allowed[cmd] = true
+	}
+
+	return breeze.ToolSpec{
+		Name:        "shell_exec",
+		Description: fmt.Sprintf("Run a shell command whose first word is one of: %s", strings.Join(allowlist, ", ")),
+		Parameters:  json.RawMessage(`{"type":"object","properties":{"command":{"type":"string"}},"required":["command"]}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Command string `json:"command"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			fields := strings.Fields(a.Command)
+			if len(fields) == 0 || !allowed[fields[0]] {
+				return "", fmt.Errorf("command %q is not on the allowlist", a.Command)
+			}
+			out, err := exec.CommandContext(ctx, "sh", "-c", a.Command).CombinedOutput()
+			if err != nil {
+				return string(out), fmt.Errorf("command failed: %w", err)
+			}
+			return string(out), nil
+		},
+	}
+}
+
+// HTTPGet returns a ToolSpec that fetches a URL over HTTP GET and returns
+// its response body as text.
+func HTTPGet() breeze.ToolSpec {
+	return breeze.ToolSpec{
+		Name:        "http_get",
+		Description: "Fetch a URL via HTTP GET and return the response body.",
+		Parameters:  json.RawMessage(`{"type":"object","properties":{"url":{"type":"string"}},"required":["url"]}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+			if err != nil {
+				return "", err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return "", err
+			}
+			return string(body), nil
+		},
+	}
+}
+
+// SearchWeb returns a ToolSpec that queries DuckDuckGo's keyless Instant
+// Answer API and returns the abstract text and related topics it finds.
+func SearchWeb() breeze.ToolSpec {
+	return breeze.ToolSpec{
+		Name:        "search_web",
+		Description: "Search the web for a query and return a short summary of relevant results.",
+		Parameters:  json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a struct {
+				Query string `json:"query"`
+			}
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", fmt.Errorf("invalid args: %w", err)
+			}
+
+			endpoint := "https://api.duckduckgo.com/?q=" + url.QueryEscape(a.Query) + "&format=json&no_html=1&skip_disambig=1"
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+			if err != nil {
+				return "", err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer resp.Body.Close()
+
+			var result struct {
+				AbstractText  string `json:"AbstractText"`
+				RelatedTopics []struct {
+					Text string `json:"Text"`
+				} `json:"RelatedTopics"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				return "", fmt.Errorf("decode search response: %w", err)
+			}
+
+			var b strings.Builder
+			if result.AbstractText != "" {
+				b.WriteString(result.AbstractText)
+				b.WriteString("\n")
+			}
+			for _, topic := range result.RelatedTopics {
+				if topic.Text == "" {
+					continue
+				}
+				b.WriteString("- ")
+				b.WriteString(topic.Text)
+				b.WriteString("\n")
+			}
+			if b.Len() == 0 {
+				return "no results found", nil
+			}
+			return strings.TrimSpace(b.String()), nil
+		},
+	}
+}
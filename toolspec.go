@@ -0,0 +1,216 @@
+package breeze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToolSpec describes a function AI or Chat can call mid-turn: Name and
+// Description are shown to the model (in the prompt, or as Ollama's native
+// "tools" field for models that support it), Parameters is the JSON schema
+// for its arguments, and Handler executes the call.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  json.RawMessage
+	Handler     func(ctx context.Context, args json.RawMessage) (string, error)
+}
+
+// ToolRegistry looks up a ToolSpec by name for the AI/Chat tool-calling
+// loop.
+type ToolRegistry struct {
+	tools map[string]ToolSpec
+}
+
+// NewToolRegistry builds a ToolRegistry from specs.
+func NewToolRegistry(specs ...ToolSpec) *ToolRegistry {
+	r := &ToolRegistry{tools: make(map[string]ToolSpec, len(specs))}
+	for _, s := range specs {
+		r.tools[s.Name] = s
+	}
+	return r
+}
+
+// Lookup returns the ToolSpec registered under name, if any.
+func (r *ToolRegistry) Lookup(name string) (ToolSpec, bool) {
+	s, ok := r.tools[name]
+	return s, ok
+}
+
+// Specs returns every registered ToolSpec, in no particular order.
+func (r *ToolRegistry) Specs() []ToolSpec {
+	specs := make([]ToolSpec, 0, len(r.tools))
+	for _, s := range r.tools {
+		specs = append(specs, s)
+	}
+	return specs
+}
+
+// ToolChoice constrains which tool(s) AI/Chat may call: ToolChoiceAuto (the
+// default, the model decides), ToolChoiceNone (never call a tool), or a
+// specific tool's Name to force that call.
+type ToolChoice string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call a tool.
+	ToolChoiceAuto ToolChoice = "auto"
+	// ToolChoiceNone disables tool calls for this request.
+	ToolChoiceNone ToolChoice = "none"
+)
+
+// WithTools attaches callable tools to the request. AI and Chat inject
+// their descriptions into the prompt (and, for Chat, also into Ollama's
+// native "tools" field), parse the reply for tool calls, invoke matching
+// Handlers, append the results, and loop until the model returns a final
+// answer or MaxToolIterations is hit.
+func WithTools(specs ...ToolSpec) Option {
+	return func(opts *RequestOptions) {
+		opts.Tools = append(opts.Tools, specs...)
+	}
+}
+
+// WithToolChoice sets which tool(s) the model may call. Defaults to
+// ToolChoiceAuto.
+func WithToolChoice(choice ToolChoice) Option {
+	return func(opts *RequestOptions) {
+		opts.ToolChoice = choice
+	}
+}
+
+// WithMaxToolIterations bounds the tool-calling loop for this request,
+// overriding defaultMaxToolIterations.
+func WithMaxToolIterations(n int) Option {
+	return func(opts *RequestOptions) {
+		opts.MaxToolIterations = n
+	}
+}
+
+// ToolCall is a single tool invocation the model requested, surfaced to
+// WithToolObserver for observability.
+type ToolCall struct {
+	Name string
+	Args json.RawMessage
+}
+
+// ToolResult is the outcome of a ToolCall, surfaced to WithToolObserver
+// alongside it.
+type ToolResult struct {
+	Output string
+	Err    error
+}
+
+// WithToolObserver registers fn to be called with each ToolCall/ToolResult
+// pair as AI/Chat work through the tool-calling loop, e.g. for logging or a
+// UI trace.
+func WithToolObserver(fn func(ToolCall, ToolResult)) Option {
+	return func(opts *RequestOptions) {
+		opts.ToolObserver = fn
+	}
+}
+
+// renderToolSpecDescriptions renders tools as a textual tool-calling
+// instruction appended to the prompt, using the same TOOL_CALL convention
+// as the Collaboration-level tool loop (see renderToolDescriptions), so
+// models without native tool-calling support can still participate.
+func renderToolSpecDescriptions(tools []ToolSpec) string {
+	var b strings.Builder
+	b.WriteString("AVAILABLE TOOLS:\n")
+	for _, t := range tools {
+		b.WriteString(fmt.Sprintf("- %s: %s\n  parameters: %s\n", t.Name, t.Description, string(t.Parameters)))
+	}
+	b.WriteString("\nTo call a tool, reply with a single line: TOOL_CALL: {\"name\": \"<tool>\", \"args\": {...}}\n")
+	b.WriteString("Otherwise, reply with your final answer.\n")
+	return b.String()
+}
+
+// ollamaToolDefs converts tools into Ollama's native "tools" request field,
+// which follows the OpenAI function-calling JSON shape.
+func ollamaToolDefs(tools []ToolSpec) []map[string]interface{} {
+	if len(tools) == 0 {
+		return nil
+	}
+	defs := make([]map[string]interface{}, len(tools))
+	for i, t := range tools {
+		var params interface{} = json.RawMessage(t.Parameters)
+		if len(t.Parameters) == 0 {
+			params = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		defs[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        t.Name,
+				"description": t.Description,
+				"parameters":  params,
+			},
+		}
+	}
+	return defs
+}
+
+func findToolSpec(tools []ToolSpec, name string) (ToolSpec, bool) {
+	for _, t := range tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return ToolSpec{}, false
+}
+
+// invokeToolSpec runs call against tools, reporting the outcome to observer
+// (if set) and returning the text to feed back to the model.
+func invokeToolSpec(ctx context.Context, tools []ToolSpec, call toolCall, observer func(ToolCall, ToolResult)) string {
+	tool, ok := findToolSpec(tools, call.Name)
+	if !ok {
+		result := ToolResult{Err: fmt.Errorf("no such tool %q", call.Name)}
+		if observer != nil {
+			observer(ToolCall{Name: call.Name, Args: call.Args}, result)
+		}
+		return fmt.Sprintf("error: no such tool %q is available", call.Name)
+	}
+
+	output, err := tool.Handler(ctx, call.Args)
+	result := ToolResult{Output: output, Err: err}
+	if observer != nil {
+		observer(ToolCall{Name: call.Name, Args: call.Args}, result)
+	}
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return output
+}
+
+// runToolCallLoop drives the textual TOOL_CALL convention against a
+// single-shot generate function: it injects tool descriptions into prompt,
+// calls generate, detects a tool call in the reply, invokes it, appends the
+// result, and re-prompts until generate returns a final answer or
+// MaxToolIterations is hit.
+func runToolCallLoop(options RequestOptions, prompt string, generate func(prompt string, options RequestOptions) string) string {
+	maxIterations := options.MaxToolIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultMaxToolIterations
+	}
+
+	turnPrompt := prompt + "\n\n" + renderToolSpecDescriptions(options.Tools)
+
+	for i := 0; i < maxIterations; i++ {
+		response := generate(turnPrompt, options)
+
+		match := toolCallPattern.FindStringSubmatch(response)
+		if match == nil {
+			return response
+		}
+
+		var call toolCall
+		if err := json.Unmarshal([]byte(match[1]), &call); err != nil {
+			return response
+		}
+
+		result := invokeToolSpec(reqCtx(options), options.Tools, call, options.ToolObserver)
+		turnPrompt = fmt.Sprintf("%s\n\nYOUR PREVIOUS REPLY:\n%s\n\nTOOL RESULT for %s:\n%s\n\nContinue, or provide your final answer.",
+			turnPrompt, response, call.Name, result)
+	}
+
+	return generate(turnPrompt, options)
+}
@@ -0,0 +1,202 @@
+package breeze
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// VerificationStatus describes the outcome of running a Verifier against a
+// phase's output.
+type VerificationStatus int
+
+const (
+	// Verified means the checker confirmed the output is correct.
+	Verified VerificationStatus = iota
+	// Refuted means the checker found a concrete counterexample.
+	Refuted
+	// Inconclusive means the checker could not decide either way.
+	Inconclusive
+)
+
+func (s VerificationStatus) String() string {
+	switch s {
+	case Verified:
+		return "Verified"
+	case Refuted:
+		return "Refuted"
+	default:
+		return "Inconclusive"
+	}
+}
+
+// VerificationResult is the structured outcome of a Verifier check.
+type VerificationResult struct {
+	Status         VerificationStatus
+	Counterexample string
+	Detail         string
+}
+
+// Verifier mechanically checks an agent's (or phase's) output against
+// ground truth, e.g. by shelling out to a CAS or proof assistant.
+type Verifier interface {
+	Verify(problem, output string) VerificationResult
+}
+
+// SymPyIntegralVerifier checks a claimed antiderivative by differentiating it
+// with SymPy and comparing the result to the original integrand via
+// simplify(). It expects the integrand to be given as a Python-syntax SymPy
+// expression in x.
+type SymPyIntegralVerifier struct {
+	Integrand string // e.g. "x**4*sin(x)/(1+x**2)**2"
+	Python    string // defaults to "python3"
+}
+
+func (v SymPyIntegralVerifier) python() string {
+	if v.Python != "" {
+		return v.Python
+	}
+	return "python3"
+}
+
+// Verify differentiates output (expected to contain a SymPy-parseable
+// antiderivative) and checks that it matches the integrand after simplify().
+func (v SymPyIntegralVerifier) Verify(problem, output string) VerificationResult {
+	candidate := extractSymPyExpr(output)
+	if candidate == "" {
+		return VerificationResult{
+			Status: Inconclusive,
+			Detail: "no parseable antiderivative found in output",
+		}
+	}
+
+	script := fmt.Sprintf(`
+import sympy
+x = sympy.symbols('x')
+candidate = sympy.sympify(%q)
+integrand = sympy.sympify(%q)
+diff = sympy.simplify(sympy.diff(candidate, x) - integrand)
+print("OK" if diff == 0 else "MISMATCH:" + str(diff))
+`, candidate, v.Integrand)
+
+	out, err := exec.Command(v.python(), "-c", script).CombinedOutput()
+	if err != nil {
+		return VerificationResult{Status: Inconclusive, Detail: fmt.Sprintf("sympy invocation failed: %v: %s", err, out)}
+	}
+
+	result := strings.TrimSpace(string(out))
+	if result == "OK" {
+		return VerificationResult{Status: Verified, Detail: "derivative matches integrand after simplification"}
+	}
+	return VerificationResult{
+		Status:         Refuted,
+		Counterexample: strings.TrimPrefix(result, "MISMATCH:"),
+		Detail:         "derivative of candidate does not simplify to the integrand",
+	}
+}
+
+// FunctionalEquationVerifier samples a candidate closed-form f at N points
+// and checks that it satisfies f(f(x)) ≈ x (or any other single-variable
+// equation supplied via EquationTemplate).
+type FunctionalEquationVerifier struct {
+	Samples          []float64 // points to test; defaults to a small fixed set
+	Tolerance        float64   // defaults to 1e-6
+	EquationTemplate string    // Python expr in x and f(x), defaults to "f(f(x)) - x"
+	Python           string
+}
+
+func (v FunctionalEquationVerifier) python() string {
+	if v.Python != "" {
+		return v.Python
+	}
+	return "python3"
+}
+
+// Verify samples the candidate function extracted from output at several
+// points and checks the functional equation holds within tolerance.
+func (v FunctionalEquationVerifier) Verify(problem, output string) VerificationResult {
+	candidate := extractSymPyExpr(output)
+	if candidate == "" {
+		return VerificationResult{Status: Inconclusive, Detail: "no parseable closed form found in output"}
+	}
+
+	samples := v.Samples
+	if len(samples) == 0 {
+		samples = []float64{0.1, 0.25, 0.5, 0.75, 0.9}
+	}
+	tolerance := v.Tolerance
+	if tolerance == 0 {
+		tolerance = 1e-6
+	}
+	equation := v.EquationTemplate
+	if equation == "" {
+		equation = "f(f(x)) - x"
+	}
+
+	var points []string
+	for _, s := range samples {
+		points = append(points, strconv.FormatFloat(s, 'g', -1, 64))
+	}
+
+	script := fmt.Sprintf(`
+import sympy
+x = sympy.symbols('x')
+expr = sympy.sympify(%q)
+f = sympy.Lambda(x, expr)
+worst = 0.0
+worst_x = None
+for xv in [%s]:
+    try:
+        val = float((%s).subs(x, xv).subs(sympy.Function('f')(x), expr.subs(x, xv)))
+    except Exception as e:
+        print("ERROR:" + str(e))
+        raise SystemExit
+    if abs(val) > worst:
+        worst = abs(val)
+        worst_x = xv
+if worst <= %s:
+    print("OK")
+else:
+    print("MISMATCH:x=%%s residual=%%s" %% (worst_x, worst))
+`, candidate, strings.Join(points, ", "), equation, strconv.FormatFloat(tolerance, 'g', -1, 64))
+
+	out, err := exec.Command(v.python(), "-c", script).CombinedOutput()
+	if err != nil {
+		return VerificationResult{Status: Inconclusive, Detail: fmt.Sprintf("sympy invocation failed: %v: %s", err, out)}
+	}
+
+	result := strings.TrimSpace(string(out))
+	if result == "OK" {
+		return VerificationResult{Status: Verified, Detail: fmt.Sprintf("%s holds within tolerance at all sample points", equation)}
+	}
+	if strings.HasPrefix(result, "MISMATCH:") {
+		return VerificationResult{
+			Status:         Refuted,
+			Counterexample: strings.TrimPrefix(result, "MISMATCH:"),
+			Detail:         fmt.Sprintf("%s failed tolerance at a sample point", equation),
+		}
+	}
+	return VerificationResult{Status: Inconclusive, Detail: result}
+}
+
+// extractSymPyExpr pulls the last line that looks like a bare math
+// expression out of free-form model output, stripping common LaTeX wrappers.
+// This is a best-effort heuristic, not a full parser.
+func extractSymPyExpr(output string) string {
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		line = strings.Trim(line, "$")
+		line = strings.TrimPrefix(line, "f(x) =")
+		line = strings.TrimPrefix(line, "F(x) =")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.ContainsAny(line, "xX") {
+			return line
+		}
+	}
+	return ""
+}